@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/your-org/go-template-project/internal/app"
+	"github.com/your-org/go-template-project/internal/config"
+)
+
+// configCmd loads the configuration from CONFIG_FILE or the environment,
+// exactly as the server would, and prints it with secrets redacted. It shows what a
+// deployment will see without starting anything.
+//
+// Usage: cli config [--format json|yaml]
+func configCmd(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+	format := fs.String("format", "json", "Output format: json or yaml")
+	if err := fs.Parse(args); err != nil {
+		return app.UsageError("config: %w", err)
+	}
+
+	var write func(io.Writer, []config.Setting) error
+	switch *format {
+	case "json":
+		write = writeSettingsJSON
+	case "yaml":
+		write = writeSettingsYAML
+	default:
+		return app.UsageError("config: unknown format %q (want json or yaml)", *format)
+	}
+
+	cfg, err := config.LoadFromFileOrEnv()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	return write(os.Stdout, cfg.Settings())
+}
+
+// writeSettingsJSON prints settings as an indented JSON object, keeping
+// them in field order.
+func writeSettingsJSON(w io.Writer, settings []config.Setting) error {
+	var buf bytes.Buffer
+	buf.WriteString("{\n")
+	for i, s := range settings {
+		value, err := json.Marshal(s.Value)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", s.Key, err)
+		}
+		key, _ := json.Marshal(s.Key)
+		fmt.Fprintf(&buf, "  %s: %s", key, value)
+		if i < len(settings)-1 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('\n')
+	}
+	buf.WriteString("}\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// writeSettingsYAML prints settings as a YAML mapping. Values are written
+// in JSON syntax, which YAML accepts, so strings are always quoted and
+// lists use flow style.
+func writeSettingsYAML(w io.Writer, settings []config.Setting) error {
+	var buf bytes.Buffer
+	for _, s := range settings {
+		value, err := json.Marshal(s.Value)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", s.Key, err)
+		}
+		fmt.Fprintf(&buf, "%s: %s\n", s.Key, value)
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/your-org/go-template-project/internal/app"
+	"github.com/your-org/go-template-project/internal/config"
+	"github.com/your-org/go-template-project/internal/httpclient"
+)
+
+// minGoVersion is the oldest Go toolchain the module builds with; keep it in
+// step with the go directive in go.mod.
+const minGoVersion = "1.23"
+
+// doctorTimeout bounds each network check.
+const doctorTimeout = 2 * time.Second
+
+// doctorCheck is one line of the doctor report. A failed critical check
+// makes doctor exit non-zero; other failures are reported as warnings.
+type doctorCheck struct {
+	name     string
+	critical bool
+	run      func(ctx context.Context) (string, error)
+}
+
+// doctor checks that the development environment can build and run the
+// project: a recent enough Go toolchain, git, and the database and upstream
+// dependencies from the configuration. It prints one PASS/FAIL/WARN line per
+// check and fails if any critical check fails.
+//
+// Usage: cli doctor
+func doctor(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return app.UsageError("doctor: %w", err)
+	}
+
+	checks := []doctorCheck{
+		{name: "go", critical: true, run: checkGoVersion},
+		{name: "git", critical: true, run: checkGit},
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		checks = append(checks, doctorCheck{name: "config", critical: true, run: func(context.Context) (string, error) {
+			return "", err
+		}})
+	} else {
+		if cfg.DatabaseURL != "" {
+			checks = append(checks, doctorCheck{name: "database", run: func(ctx context.Context) (string, error) {
+				return checkDatabase(ctx, cfg.DatabaseURL)
+			}})
+		}
+		// Upstreams are reported by host, as on /ready
+		for _, raw := range cfg.DependencyURLs {
+			name := raw
+			if u, err := url.Parse(raw); err == nil {
+				name = u.Host
+			}
+			checks = append(checks, doctorCheck{name: name, run: func(ctx context.Context) (string, error) {
+				return checkDependency(ctx, raw)
+			}})
+		}
+	}
+
+	failed := 0
+	for _, check := range checks {
+		detail, err := check.run(ctx)
+
+		status := "PASS"
+		if err != nil {
+			detail = err.Error()
+			status = "WARN"
+			if check.critical {
+				status = "FAIL"
+				failed++
+			}
+		}
+		fmt.Printf("%-4s  %-8s  %s\n", status, check.name, detail)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("doctor: %d critical check(s) failed", failed)
+	}
+	return nil
+}
+
+// checkGoVersion reports the go toolchain on PATH and fails if it is older
+// than minGoVersion.
+func checkGoVersion(ctx context.Context) (string, error) {
+	out, err := exec.CommandContext(ctx, "go", "env", "GOVERSION").Output()
+	if err != nil {
+		return "", fmt.Errorf("go toolchain not found: %w", err)
+	}
+
+	version := strings.TrimSpace(string(out))
+	if !atLeastGoVersion(version, minGoVersion) {
+		return "", fmt.Errorf("%s is older than the required go%s", version, minGoVersion)
+	}
+	return version, nil
+}
+
+// atLeastGoVersion reports whether version (e.g. "go1.23.4") is at least
+// min (e.g. "1.23"), comparing major and minor numbers.
+func atLeastGoVersion(version, min string) bool {
+	have := strings.Split(strings.TrimPrefix(version, "go"), ".")
+	want := strings.Split(min, ".")
+	for i := 0; i < 2; i++ {
+		var h, w int
+		if i < len(have) {
+			// Trim pre-release suffixes such as "rc1"
+			h, _ = strconv.Atoi(strings.TrimRightFunc(have[i], func(r rune) bool { return r < '0' || r > '9' }))
+		}
+		if i < len(want) {
+			w, _ = strconv.Atoi(want[i])
+		}
+		if h != w {
+			return h > w
+		}
+	}
+	return true
+}
+
+// checkGit reports where git is installed.
+func checkGit(ctx context.Context) (string, error) {
+	path, err := exec.LookPath("git")
+	if err != nil {
+		return "", fmt.Errorf("git not found on PATH")
+	}
+	return path, nil
+}
+
+// checkDatabase dials the DATABASE_URL host. It only proves the server is
+// reachable; credentials are checked by the application's driver.
+func checkDatabase(ctx context.Context, raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Hostname() == "" {
+		return "", fmt.Errorf("DATABASE_URL has no host")
+	}
+
+	host := u.Host
+	if u.Port() == "" {
+		port, ok := map[string]string{"postgres": "5432", "postgresql": "5432", "mysql": "3306"}[u.Scheme]
+		if !ok {
+			return "", fmt.Errorf("DATABASE_URL has no port")
+		}
+		host = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	dialer := net.Dialer{Timeout: doctorTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return "", fmt.Errorf("%s://%s unreachable: %w", u.Scheme, host, err)
+	}
+	conn.Close()
+	return fmt.Sprintf("%s://%s reachable", u.Scheme, host), nil
+}
+
+// checkDependency expects a 2xx from an upstream in DEPENDENCY_URLS.
+func checkDependency(ctx context.Context, raw string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, doctorTimeout)
+	defer cancel()
+
+	resp, err := httpclient.New(0).Get(ctx, raw)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp.Status, nil
+}
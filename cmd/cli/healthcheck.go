@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/your-org/go-template-project/internal/app"
+	"github.com/your-org/go-template-project/internal/httpclient"
+)
+
+// healthcheck probes a server's health endpoint and fails unless it answers
+// 200 OK within the timeout, retrying connection errors and 5xx responses.
+// It lets distroless images run HEALTHCHECK without curl or wget.
+//
+// Usage: cli healthcheck [--url http://localhost:8080/health] [--timeout 3s]
+func healthcheck(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("healthcheck", flag.ContinueOnError)
+	url := fs.String("url", "http://localhost:8080/health", "Health endpoint to probe")
+	timeout := fs.Duration("timeout", 3*time.Second, "Overall timeout, including retries")
+	if err := fs.Parse(args); err != nil {
+		return app.UsageError("healthcheck: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	// Retry transient failures, such as a server still binding its port
+	resp, err := httpclient.New(0).Get(ctx, *url)
+	if err != nil {
+		return fmt.Errorf("health check failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	fmt.Printf("%s %s\n", *url, resp.Status)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health check failed: %s", resp.Status)
+	}
+	return nil
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/your-org/go-template-project/internal/app"
 )
@@ -15,16 +18,35 @@ const (
 
 func main() {
 	showVersion := flag.Bool("version", false, "Show version information")
+	jsonOutput := flag.Bool("json", false, "Print application and build info as JSON")
 	flag.Parse()
 
 	if *showVersion {
-		log.Printf("%s version %s", appName, appVersion)
+		build := app.ReadBuildInfo()
+		log.Printf("%s version %s (%s, %s)", appName, appVersion, build.GoVersion, build.Platform())
 		os.Exit(0)
 	}
 
 	application := app.New(appName, appVersion)
+	application.Args = flag.Args()
+	application.Register("healthcheck", healthcheck)
+	application.Register("config", configCmd)
+	application.Register("doctor", doctor)
 
-	if err := application.Run(); err != nil {
-		log.Fatal(err)
+	if *jsonOutput {
+		if err := application.WriteJSON(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// Cancel running commands on Ctrl+C or SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := application.RunContext(ctx); err != nil {
+		log.Print(err)
+		stop()
+		os.Exit(app.ExitCode(err))
 	}
 }
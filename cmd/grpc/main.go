@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/your-org/go-template-project/internal/config"
+)
+
+const (
+	appName    = "go-template-grpc"
+	appVersion = "1.0.0"
+
+	defaultGRPCPort = "9090"
+)
+
+// GRPCServer owns the listener lifecycle for the gRPC service. It is a
+// stub: the template stays dependency-free, so no gRPC runtime is wired in
+// and every connection is closed as soon as it is accepted. To serve real
+// RPCs:
+//  1. Run `make proto` to generate Go code from proto/
+//  2. Add google.golang.org/grpc and create a *grpc.Server in Serve
+//  3. Register the generated service implementations on it
+type GRPCServer struct {
+	config   *config.Config
+	listener net.Listener
+}
+
+// NewGRPCServer creates a server listening on addr. A nil cfg uses
+// config.Default().
+func NewGRPCServer(cfg *config.Config, addr string) (*GRPCServer, error) {
+	if cfg == nil {
+		cfg = config.Default()
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	return &GRPCServer{
+		config:   cfg,
+		listener: listener,
+	}, nil
+}
+
+// Serve accepts connections and closes them unanswered until the listener
+// is closed. Replace the body with grpcServer.Serve(s.listener) once
+// services are registered.
+func (s *GRPCServer) Serve() error {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+
+		if s.config.Debug {
+			log.Printf("📋 Connection from %s (no gRPC services registered)", conn.RemoteAddr())
+		}
+		conn.Close()
+	}
+}
+
+// Stop closes the listener, ending Serve.
+func (s *GRPCServer) Stop() error {
+	return s.listener.Close()
+}
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	config.LogEffective(slog.Default(), appName, cfg)
+
+	port := os.Getenv("GRPC_PORT")
+	if port == "" {
+		port = defaultGRPCPort
+	}
+	addr := net.JoinHostPort(cfg.Host, port)
+
+	server, err := NewGRPCServer(cfg, addr)
+	if err != nil {
+		log.Fatalf("Failed to create gRPC server: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		log.Printf("🚀 %s v%s listening on %s", appName, appVersion, addr)
+		log.Println("⚠️  gRPC stub: no services are registered, so connections are closed unanswered (see cmd/grpc/main.go)")
+		if err := server.Serve(); err != nil {
+			log.Fatalf("gRPC server failed: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Println("🛑 gRPC server shutting down...")
+
+	if err := server.Stop(); err != nil {
+		log.Printf("⚠️  Failed to stop gRPC server: %v", err)
+	}
+
+	log.Println("✅ gRPC server exited gracefully")
+}
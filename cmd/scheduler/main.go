@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/your-org/go-template-project/internal/config"
+	"github.com/your-org/go-template-project/internal/cron"
+)
+
+const (
+	appName    = "go-template-scheduler"
+	appVersion = "1.0.0"
+)
+
+// Job is a one-shot unit of scheduled work. Schedule is a cron expression
+// (see package cron); Run should return ctx.Err() once ctx is cancelled.
+type Job struct {
+	Name     string
+	Schedule string
+	Run      func(ctx context.Context, cfg *config.Config) error
+}
+
+// jobs is the scheduler's job table. Add your jobs here.
+var jobs = []Job{
+	{Name: "heartbeat", Schedule: "*/5 * * * *", Run: heartbeat},
+}
+
+// heartbeat is an example job standing in for real work.
+func heartbeat(ctx context.Context, cfg *config.Config) error {
+	log.Printf("💓 %s heartbeat (debug=%t)", appName, cfg.Debug)
+	return ctx.Err()
+}
+
+// runDue runs each job whose schedule matches now, one after another, and
+// returns the combined errors of those that failed. Unlike the worker, the
+// scheduler is meant to be started by an external trigger (cron, a
+// Kubernetes CronJob) and exit once its jobs are done.
+func runDue(ctx context.Context, cfg *config.Config, jobs []Job, now time.Time) error {
+	var errs []error
+	for _, job := range jobs {
+		schedule, err := cron.Parse(job.Schedule)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("job %s: %w", job.Name, err))
+			continue
+		}
+		if !schedule.Matches(now) {
+			continue
+		}
+
+		if err := runJob(ctx, cfg, job); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runJob runs a single job and logs its outcome.
+func runJob(ctx context.Context, cfg *config.Config, job Job) error {
+	log.Printf("⏰ Running job %s", job.Name)
+	start := time.Now()
+
+	if err := job.Run(ctx, cfg); err != nil {
+		log.Printf("❌ Job %s failed after %s: %v", job.Name, time.Since(start), err)
+		return fmt.Errorf("job %s: %w", job.Name, err)
+	}
+
+	log.Printf("✅ Job %s completed in %s", job.Name, time.Since(start))
+	return nil
+}
+
+// findJob returns the job with the given name.
+func findJob(name string) (Job, bool) {
+	for _, job := range jobs {
+		if job.Name == name {
+			return job, true
+		}
+	}
+	return Job{}, false
+}
+
+// listJobs prints each job with its schedule and next due time.
+func listJobs(now time.Time) error {
+	for _, job := range jobs {
+		schedule, err := cron.Parse(job.Schedule)
+		if err != nil {
+			return fmt.Errorf("job %s: %w", job.Name, err)
+		}
+		fmt.Printf("%-20s %-15s next: %s\n", job.Name, job.Schedule, schedule.Next(now).Format(time.RFC3339))
+	}
+	return nil
+}
+
+func main() {
+	jobName := flag.String("job", "", "Run the named job now, regardless of its schedule")
+	list := flag.Bool("list", false, "List jobs with their schedules and next run times")
+	flag.Parse()
+
+	if *list {
+		if err := listJobs(time.Now()); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	config.LogEffective(slog.Default(), appName, cfg)
+
+	// Cancel running jobs on Ctrl+C or SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if *jobName != "" {
+		job, ok := findJob(*jobName)
+		if !ok {
+			log.Fatalf("Unknown job %q (see --list)", *jobName)
+		}
+		err = runJob(ctx, cfg, job)
+	} else {
+		err = runDue(ctx, cfg, jobs, time.Now())
+	}
+
+	if err != nil {
+		stop()
+		log.Printf("Scheduler finished with errors: %v", err)
+		os.Exit(1)
+	}
+}
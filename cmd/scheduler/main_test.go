@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/your-org/go-template-project/internal/config"
+)
+
+func TestRunDueRunsOnlyMatchingJobs(t *testing.T) {
+	var ran []string
+	record := func(name string, err error) func(context.Context, *config.Config) error {
+		return func(context.Context, *config.Config) error {
+			ran = append(ran, name)
+			return err
+		}
+	}
+
+	testJobs := []Job{
+		{Name: "every-minute", Schedule: "* * * * *", Run: record("every-minute", nil)},
+		{Name: "at-three", Schedule: "0 3 * * *", Run: record("at-three", nil)},
+		{Name: "failing", Schedule: "* * * * *", Run: record("failing", errors.New("boom"))},
+	}
+
+	now := time.Date(2024, 1, 10, 10, 7, 0, 0, time.UTC)
+	err := runDue(context.Background(), &config.Config{}, testJobs, now)
+
+	if err == nil {
+		t.Error("Expected error from the failing job")
+	}
+	if len(ran) != 2 || ran[0] != "every-minute" || ran[1] != "failing" {
+		t.Errorf("Expected every-minute and failing to run, got %v", ran)
+	}
+}
+
+func TestRunDueReportsInvalidSchedule(t *testing.T) {
+	testJobs := []Job{
+		{Name: "broken", Schedule: "not a schedule", Run: func(context.Context, *config.Config) error { return nil }},
+	}
+
+	if err := runDue(context.Background(), &config.Config{}, testJobs, time.Now()); err == nil {
+		t.Error("Expected error for an invalid schedule")
+	}
+}
+
+func TestJobTableSchedulesParse(t *testing.T) {
+	if err := listJobs(time.Now()); err != nil {
+		t.Errorf("Expected every job schedule to parse, got %v", err)
+	}
+}
@@ -2,15 +2,32 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"log/slog"
+	"maps"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
+	"github.com/your-org/go-template-project/internal/app"
 	"github.com/your-org/go-template-project/internal/config"
+	"github.com/your-org/go-template-project/internal/features"
 	"github.com/your-org/go-template-project/internal/handlers"
+	"github.com/your-org/go-template-project/internal/logging"
+	"github.com/your-org/go-template-project/internal/metrics"
+	"github.com/your-org/go-template-project/internal/netutil"
+	"github.com/your-org/go-template-project/internal/tlsutil"
+	"github.com/your-org/go-template-project/internal/tracing"
 )
 
 const (
@@ -18,56 +35,445 @@ const (
 	appVersion = "1.0.0"
 )
 
-func main() {
-	cfg, err := config.Load()
-	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
+// newServer wires the router into an http.Server and verifies the
+// operational routes are reachable before returning. A nil tracer disables
+// request tracing, and a nil cfg uses config.Default(). Enabling maintenance
+// fails /ready so the instance is taken out of rotation.
+func newServer(cfg *config.Config, tracer *tracing.Tracer, maintenance *handlers.Maintenance) (*http.Server, error) {
+	if cfg == nil {
+		cfg = config.Default()
 	}
 
-	mux := http.NewServeMux()
+	// Register dependency checks (database, upstream APIs) on readiness
+	readiness := handlers.NewReadinessRegistry(cfg.ReadinessConcurrency)
+	readiness.SetTimeout(cfg.ReadinessTimeout)
 
-	// Health endpoints
-	mux.HandleFunc("/health", handlers.HealthCheck(appVersion))
-	mux.HandleFunc("/ready", handlers.ReadinessCheck())
+	// Maintenance mode drains the instance by failing readiness only
+	readiness.Register("maintenance", maintenance.Check)
 
-	// Example API endpoint
-	mux.HandleFunc("/api/info", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		_, err := w.Write([]byte(`{"name":"` + appName + `","version":"` + appVersion + `"}`))
-		if err != nil {
-			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	// Upstream APIs from DEPENDENCY_URLS are reported by host
+	for _, raw := range cfg.DependencyURLs {
+		name := raw
+		if u, err := url.Parse(raw); err == nil {
+			name = u.Host
 		}
+		readiness.Register(name, handlers.HTTPDependencyCheck(name, raw))
+	}
+
+	// Fail readiness when the data volume is full or read-only
+	if cfg.DiskCheckPath != "" {
+		readiness.Register("disk", handlers.DiskCheck(cfg.DiskCheckPath, cfg.DiskCheckMinFreeBytes))
+	}
+
+	// A nil collector leaves /metrics and /metrics.json unregistered
+	var collector *metrics.Collector
+	if cfg.EnableMetrics {
+		collector = metrics.NewWithBuckets(cfg.MetricsBuckets)
+	}
+
+	// A nil map leaves security headers off
+	var securityHeaders map[string]string
+	if cfg.SecurityHeaders {
+		securityHeaders = handlers.DefaultSecurityHeaders()
+		maps.Copy(securityHeaders, cfg.SecurityHeaderOverrides)
+	}
+
+	router := handlers.NewRouter(handlers.RouterOptions{
+		Name:        appName,
+		Version:     appVersion,
+		Commit:      app.ReadBuildInfo().Revision,
+		Readiness:   readiness,
+		Metrics:     collector,
+		DisableInfo: cfg.DisableInfoEndpoint,
+
+		Maintenance: maintenance,
+		AdminAPIKey: cfg.AdminAPIKey,
+
+		AccessLogMode:  cfg.AccessLogMode,
+		HandlerTimeout: cfg.HandlerTimeout,
+		TrustProxy:     cfg.TrustProxy,
+		ForceHTTPS:     cfg.ForceHTTPS,
+		Tracer:         tracer,
+		BasePath:       cfg.BasePath,
+		PrettyJSON:     cfg.PrettyJSON,
+
+		SecurityHeaders:    securityHeaders,
+		CORSAllowedOrigins: cfg.CORSAllowedOrigins,
 	})
 
+	if err := router.SelfCheck(); err != nil {
+		return nil, fmt.Errorf("server self-check failed: %w", err)
+	}
+
 	server := &http.Server{
-		Addr:         cfg.Address(),
-		Handler:      mux,
-		ReadTimeout:  cfg.ReadTimeout,
-		WriteTimeout: cfg.WriteTimeout,
+		Addr:           cfg.Address(),
+		Handler:        router,
+		ReadTimeout:    cfg.ReadTimeout,
+		WriteTimeout:   cfg.WriteTimeout,
+		IdleTimeout:    cfg.IdleTimeout,
+		MaxHeaderBytes: cfg.MaxHeaderBytes,
 	}
+	server.SetKeepAlivesEnabled(cfg.KeepAlives)
+	return server, nil
+}
 
-	// Start server in a goroutine
-	go func() {
-		log.Printf("🚀 Server starting on %s", cfg.Address())
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Server failed to start: %v", err)
+// run serves until ctx is cancelled, then shuts down gracefully. It returns
+// an error if the server cannot start or stops unexpectedly. A nil cfg uses
+// config.Default().
+func run(ctx context.Context, cfg *config.Config) error {
+	if cfg == nil {
+		cfg = config.Default()
+	}
+
+	config.LogEffective(slog.Default(), appName, cfg)
+
+	// Tracing is a no-op unless OTEL_EXPORTER_OTLP_ENDPOINT is set
+	tracer, shutdownTracing := tracing.FromEnv(appName)
+
+	maintenance := &handlers.Maintenance{}
+	server, err := newServer(cfg, tracer, maintenance)
+	if err != nil {
+		return fmt.Errorf("failed to build server: %w", err)
+	}
+
+	// Serve HTTPS when a certificate is configured; the reloader picks up
+	// rotated certificates without a restart
+	if cfg.TLSEnabled() {
+		reloader, err := tlsutil.NewCertReloader(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load TLS certificate: %w", err)
 		}
-	}()
+		server.TLSConfig = reloader.TLSConfig()
+	}
 
-	// Wait for interrupt signal to gracefully shutdown
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	listeners, err := listenAll(cfg)
+	if err != nil {
+		return err
+	}
+	// Unlink our socket files however run returns, even when Shutdown times
+	// out, so a restart doesn't fail with "address already in use"
+	defer removeSockets(ownedSockets(cfg))
+
+	// Serve every listener from its own goroutine with the shared handler;
+	// Shutdown closes them all
+	serveErr := make(chan error, len(listeners))
+	for _, listener := range listeners {
+		go func(listener net.Listener) {
+			log.Printf("🚀 Server starting on %s (tls=%t, max_connections=%d)",
+				listener.Addr(), cfg.TLSEnabled(), cfg.MaxConnections)
+
+			if cfg.TLSEnabled() {
+				serveErr <- server.ServeTLS(listener, "", "")
+			} else {
+				serveErr <- server.Serve(listener)
+			}
+		}(listener)
+	}
+
+	select {
+	case err := <-serveErr:
+		server.Close()
+		return fmt.Errorf("server failed: %w", err)
+	case <-ctx.Done():
+	}
+
+	// Fail readiness first so load balancers stop routing here, and keep
+	// serving while they catch up before closing the listeners
+	maintenance.Set(true)
+	if cfg.PreStopDelay > 0 {
+		log.Printf("⏳ Readiness failing; waiting %s before shutting down", cfg.PreStopDelay)
+		time.Sleep(cfg.PreStopDelay)
+	}
 
-	log.Println("🛑 Server shutting down...")
+	// Close connections as their in-flight responses finish instead of
+	// keeping them open for more requests, so draining frees them promptly
+	server.SetKeepAlivesEnabled(false)
 
 	// Give outstanding requests 30 seconds to complete
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	if err := server.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("server forced to shutdown: %w", err)
+	}
+
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		log.Printf("Failed to flush traces: %v", err)
 	}
 
 	log.Println("✅ Server exited gracefully")
+	return nil
+}
+
+// listenAll opens a listener for every configured address. Entries prefixed
+// with "unix:" become Unix domain sockets; a stale socket file left by a
+// previous run is removed first (see removeStaleSocket). If any address
+// fails, the listeners already opened are closed.
+func listenAll(cfg *config.Config) ([]net.Listener, error) {
+	var listeners []net.Listener
+	for _, addr := range cfg.Listeners() {
+		network, address := "tcp", addr
+		if path, ok := strings.CutPrefix(addr, config.UnixPrefix); ok {
+			network, address = "unix", path
+			if err := removeStaleSocket(path); err != nil {
+				closeAll(listeners)
+				return nil, fmt.Errorf("server failed to listen on %s: %w", addr, err)
+			}
+		}
+
+		var listener net.Listener
+		var err error
+		if network == "tcp" {
+			listener, err = listenTCP(address, cfg.AutoPort)
+		} else {
+			listener, err = net.Listen(network, address)
+		}
+		if err != nil {
+			closeAll(listeners)
+			return nil, fmt.Errorf("server failed to listen on %s: %w", addr, err)
+		}
+
+		// Bound simultaneous connections to avoid file-descriptor exhaustion
+		if cfg.MaxConnections > 0 {
+			listener = netutil.LimitListener(listener, cfg.MaxConnections)
+		}
+		listeners = append(listeners, listener)
+	}
+	return listeners, nil
+}
+
+// removeStaleSocket deletes the socket file a previous run left at path.
+// Anything that isn't a socket, such as a mistyped path to a regular file,
+// is left alone, as is a socket another instance still accepts connections
+// on; both are reported as errors.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s exists and is not a socket", path)
+	}
+
+	if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		conn.Close()
+		return fmt.Errorf("socket %s is in use by another process", path)
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove stale socket %s: %w", path, err)
+	}
+	return nil
+}
+
+// autoPortAttempts is how many following ports AUTO_PORT tries.
+const autoPortAttempts = 10
+
+// listenTCP listens on addr. When the port is taken it tries the next
+// autoPortAttempts ports if autoPort is set, and otherwise explains how to
+// pick another port instead of returning a bare "address already in use".
+func listenTCP(addr string, autoPort bool) (net.Listener, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err == nil || !errors.Is(err, syscall.EADDRINUSE) {
+		return listener, err
+	}
+
+	host, portStr, splitErr := net.SplitHostPort(addr)
+	port, atoiErr := strconv.Atoi(portStr)
+	if splitErr != nil || atoiErr != nil {
+		return nil, err
+	}
+
+	if autoPort {
+		for next := port + 1; next <= port+autoPortAttempts && next <= 65535; next++ {
+			listener, nextErr := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(next)))
+			if nextErr == nil {
+				log.Printf("⚠️  Port %d is in use, listening on %d instead (AUTO_PORT)", port, next)
+				return listener, nil
+			}
+			if !errors.Is(nextErr, syscall.EADDRINUSE) {
+				return nil, nextErr
+			}
+		}
+		return nil, fmt.Errorf("ports %d-%d are all in use: %w", port, port+autoPortAttempts, err)
+	}
+
+	return nil, fmt.Errorf("port %d is already in use by another process; "+
+		"set PORT to a free port, or AUTO_PORT=true to try the next ones: %w", port, err)
+}
+
+// closeAll closes every listener, ignoring errors.
+func closeAll(listeners []net.Listener) {
+	for _, listener := range listeners {
+		listener.Close()
+	}
+}
+
+// ownedSockets records the socket file behind every "unix:" listen
+// address, just after listenAll created them, so removeSockets can tell them
+// apart from a file that later replaced them.
+func ownedSockets(cfg *config.Config) map[string]os.FileInfo {
+	owned := make(map[string]os.FileInfo)
+	for _, addr := range cfg.Listeners() {
+		path, ok := strings.CutPrefix(addr, config.UnixPrefix)
+		if !ok {
+			continue
+		}
+		if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+			owned[path] = info
+		}
+	}
+	return owned
+}
+
+// removeSockets deletes each socket file in owned that is still the one
+// this process created. Closing a Unix listener normally unlinks it, so a
+// missing file is fine, and anything else now at the path is left alone.
+func removeSockets(owned map[string]os.FileInfo) {
+	for path, created := range owned {
+		info, err := os.Lstat(path)
+		if err != nil || info.Mode()&os.ModeSocket == 0 || !os.SameFile(info, created) {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			log.Printf("Failed to remove socket %s: %v", path, err)
+		}
+	}
+}
+
+// liveConfig holds the server's current configuration, the one baseline
+// that reloads from SIGHUP and from a watched config file are applied to.
+type liveConfig struct {
+	mu  sync.Mutex
+	cfg atomic.Pointer[config.Config]
+}
+
+func newLiveConfig(cfg *config.Config) *liveConfig {
+	l := &liveConfig{}
+	l.cfg.Store(cfg)
+	return l
+}
+
+// Load returns the current configuration.
+func (l *liveConfig) Load() *config.Config {
+	return l.cfg.Load()
+}
+
+// apply takes the hot-reloadable fields from next, leaving the rest for the
+// next restart, and reports whether anything changed.
+func (l *liveConfig) apply(next *config.Config) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	applied, changed := config.ApplyHotReload(l.cfg.Load(), next)
+	if changed {
+		l.cfg.Store(applied)
+	}
+	return changed
+}
+
+// reload applies next's hot-reloadable fields and puts them into effect,
+// logging the outcome for the reload trigger named by source.
+func (l *liveConfig) reload(next *config.Config, source string) {
+	if !l.apply(next) {
+		log.Printf("ℹ️  %s: no reloadable config changes", source)
+		return
+	}
+	applyLogLevel(l.Load())
+	log.Printf("🔄 Config reloaded from %s (log_level=%s)", source, l.Load().LogLevel)
+}
+
+// loadConfig reads the initial configuration, watching CONFIG_FILE for
+// hot-reloadable changes when it is set.
+func loadConfig() (*liveConfig, func(), error) {
+	path := os.Getenv(config.FileEnv)
+	if path == "" {
+		cfg, err := config.Load()
+		if err != nil {
+			return nil, nil, err
+		}
+		return newLiveConfig(cfg), func() {}, nil
+	}
+
+	live := &liveConfig{}
+	cfg, stop, err := config.Watch(path, func(next *config.Config) {
+		live.reload(next, path)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	live.cfg.Store(cfg)
+	return live, stop, nil
+}
+
+// applyLogLevel makes cfg.LogLevel the level of the default slog logger.
+func applyLogLevel(cfg *config.Config) {
+	if err := logging.SetLevel(cfg.LogLevel); err != nil {
+		log.Printf("⚠️  Keeping log level %s: %v", logging.Level(), err)
+	}
+}
+
+// reloadOnSignal rereads the configuration each time a signal arrives on
+// sig and applies its hot-reloadable fields. Invalid configuration is
+// logged and ignored.
+func reloadOnSignal(sig <-chan os.Signal, live *liveConfig) {
+	for range sig {
+		next, err := config.LoadFromFileOrEnv()
+		if err != nil {
+			log.Printf("⚠️  Ignoring SIGHUP reload: %v", err)
+			continue
+		}
+
+		live.reload(next, "SIGHUP")
+	}
+}
+
+func main() {
+	live, stopWatching, err := loadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	defer stopWatching()
+
+	if err := logging.Install(os.Stderr, live.Load().LogLevel); err != nil {
+		log.Fatalf("Failed to set up logging: %v", err)
+	}
+
+	// Read FEATURE_* toggles once so malformed values are logged at startup
+	features.Refresh()
+	log.Printf("🚩 Feature flags enabled: %v", features.List())
+
+	// SIGHUP rereads the configuration without dropping connections
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go reloadOnSignal(hup, live)
+
+	// SIGUSR1 dumps every goroutine's stack to stderr to diagnose hangs
+	// (Unix only)
+	app.NotifyGoroutineDumps(os.Stderr)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Cancel on the first interrupt signal to shut down gracefully; a second
+	// signal exits immediately
+	quit := make(chan os.Signal, 2)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		sig := awaitShutdown(quit, func(sig os.Signal) {
+			log.Printf("⚠️  Received %s again, forcing exit", sig)
+			os.Exit(1)
+		})
+
+		log.Printf("🛑 Received %s, server shutting down (send again to force exit)...", sig)
+		cancel()
+	}()
+
+	if err := run(ctx, live.Load()); err != nil {
+		log.Fatalf("Server error: %v", err)
+	}
 }
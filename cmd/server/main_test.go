@@ -0,0 +1,618 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/your-org/go-template-project/internal/config"
+	"github.com/your-org/go-template-project/internal/handlers"
+	"github.com/your-org/go-template-project/internal/logging"
+)
+
+func testConfig(t *testing.T) *config.Config {
+	t.Helper()
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	cfg.Host = "127.0.0.1"
+	cfg.Port = 0
+	return cfg
+}
+
+func TestRunShutsDownOnCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- run(ctx, testConfig(t))
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("run() returned error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("run() did not return after context was cancelled")
+	}
+}
+
+func TestRunNilConfigUsesDefault(t *testing.T) {
+	server, err := newServer(nil, nil, &handlers.Maintenance{})
+	if err != nil {
+		t.Fatalf("newServer(nil) returned error: %v", err)
+	}
+	defaults := config.Default()
+	if server.ReadTimeout != defaults.ReadTimeout || server.MaxHeaderBytes != defaults.MaxHeaderBytes {
+		t.Errorf("Expected default timeouts and limits, got %+v", server)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// The default port may be taken on this machine; either way run must
+	// return rather than panic
+	done := make(chan error, 1)
+	go func() {
+		done <- run(ctx, nil)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil && !strings.Contains(err.Error(), "already in use") {
+			t.Errorf("run(nil) returned unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("run(nil) did not return after context was cancelled")
+	}
+}
+
+func TestNewServerAllowsConfiguredOrigins(t *testing.T) {
+	cfg := config.Default()
+	cfg.CORSAllowedOrigins = []string{"https://app.example.com"}
+
+	server, err := newServer(cfg, nil, &handlers.Maintenance{})
+	if err != nil {
+		t.Fatalf("newServer() returned error: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/info", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	rr := httptest.NewRecorder()
+	server.Handler.ServeHTTP(rr, req)
+
+	if got := rr.Header().Get("Access-Control-Allow-Origin"); got != "https://app.example.com" {
+		t.Errorf("Expected CORS_ALLOWED_ORIGINS to reach the router, got %q", got)
+	}
+}
+
+func TestRunReturnsListenError(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Host = "invalid host"
+
+	if err := run(context.Background(), cfg); err == nil {
+		t.Error("Expected error for unusable address")
+	}
+}
+
+// freeAddr returns a loopback address with a port that was free a moment ago.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	return l.Addr().String()
+}
+
+// waitForHealth polls url until it returns 200 or the deadline passes.
+func waitForHealth(t *testing.T, client *http.Client, url string) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		resp, err := client.Get(url)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return
+			}
+			err = fmt.Errorf("status %d", resp.StatusCode)
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s never became healthy: %v", url, err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestRunServesEveryListenAddress(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.ListenAddresses = []string{freeAddr(t), freeAddr(t)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- run(ctx, cfg)
+	}()
+
+	for _, addr := range cfg.ListenAddresses {
+		waitForHealth(t, http.DefaultClient, "http://"+addr+"/health")
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("run() returned error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("run() did not return after context was cancelled")
+	}
+
+	for _, addr := range cfg.ListenAddresses {
+		if conn, err := net.Dial("tcp", addr); err == nil {
+			conn.Close()
+			t.Errorf("Expected %s to be closed after shutdown", addr)
+		}
+	}
+}
+
+func TestRunFailsReadinessBeforeShutdown(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.ListenAddresses = []string{freeAddr(t)}
+	cfg.PreStopDelay = 300 * time.Millisecond
+	base := "http://" + cfg.ListenAddresses[0]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- run(ctx, cfg)
+	}()
+
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	waitForHealth(t, client, base+"/ready")
+
+	cancel()
+	start := time.Now()
+
+	// Still serving during the delay, but out of rotation
+	var status int
+	for time.Since(start) < cfg.PreStopDelay {
+		resp, err := client.Get(base + "/ready")
+		if err != nil {
+			t.Fatalf("Expected the server to keep serving during the delay: %v", err)
+		}
+		resp.Body.Close()
+		if status = resp.StatusCode; status == http.StatusServiceUnavailable {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if status != http.StatusServiceUnavailable {
+		t.Fatalf("Expected /ready to return 503 before shutdown, last got %d", status)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("run() returned error: %v", err)
+		}
+		if elapsed := time.Since(start); elapsed < cfg.PreStopDelay {
+			t.Errorf("Expected shutdown to wait the %v delay, returned after %v", cfg.PreStopDelay, elapsed)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("run() did not return after the delay")
+	}
+}
+
+func TestRunQueuesConnectionsOverLimit(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.ListenAddresses = []string{freeAddr(t)}
+	cfg.MaxConnections = 1
+	url := "http://" + cfg.ListenAddresses[0] + "/health"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go run(ctx, cfg)
+
+	// Close each connection after its request so probes don't hold the slot
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	waitForHealth(t, client, url)
+
+	// Occupy the only connection slot
+	held, err := net.Dial("tcp", cfg.ListenAddresses[0])
+	if err != nil {
+		t.Fatalf("Failed to open connection: %v", err)
+	}
+	defer held.Close()
+
+	result := make(chan error, 1)
+	go func() {
+		resp, err := client.Get(url)
+		if err == nil {
+			resp.Body.Close()
+		}
+		result <- err
+	}()
+
+	select {
+	case err := <-result:
+		t.Fatalf("Expected request over the limit to wait, got err=%v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	// Freeing the slot lets the queued connection through
+	held.Close()
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Errorf("Queued request failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected queued request to complete once a slot was freed")
+	}
+}
+
+func TestRunServesUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "server.sock")
+
+	cfg := testConfig(t)
+	cfg.ListenAddresses = []string{"unix:" + socket}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- run(ctx, cfg)
+	}()
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socket)
+		},
+	}}
+	waitForHealth(t, client, "http://unix/health")
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("run() returned error: %v", err)
+	}
+}
+
+func TestRunRemovesUnixSocketOnShutdown(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "server.sock")
+
+	cfg := testConfig(t)
+	cfg.ListenAddresses = []string{"unix:" + socket, freeAddr(t)}
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socket)
+		},
+	}}
+
+	// A second run on the same path must not hit "address already in use"
+	for attempt := 1; attempt <= 2; attempt++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			done <- run(ctx, cfg)
+		}()
+
+		waitForHealth(t, client, "http://unix/health")
+		client.CloseIdleConnections()
+
+		cancel()
+		if err := <-done; err != nil {
+			t.Fatalf("run() attempt %d returned error: %v", attempt, err)
+		}
+		if _, err := os.Stat(socket); !os.IsNotExist(err) {
+			t.Fatalf("Expected socket file removed after attempt %d, got stat err=%v", attempt, err)
+		}
+	}
+}
+
+func TestRemoveSockets(t *testing.T) {
+	dir := t.TempDir()
+	listen := func(path string) net.Listener {
+		t.Helper()
+		listener, err := net.Listen("unix", path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		listener.(*net.UnixListener).SetUnlinkOnClose(false)
+		return listener
+	}
+
+	ours := filepath.Join(dir, "ours.sock")
+	replaced := filepath.Join(dir, "replaced.sock")
+	overwritten := filepath.Join(dir, "overwritten.sock")
+
+	cfg := testConfig(t)
+	cfg.ListenAddresses = []string{"unix:" + ours, "unix:" + replaced, "unix:" + overwritten, "127.0.0.1:0"}
+	for _, path := range []string{ours, replaced, overwritten} {
+		defer listen(path).Close()
+	}
+	owned := ownedSockets(cfg)
+
+	// Another instance took over one path, and a regular file the other
+	for _, path := range []string{replaced, overwritten} {
+		if err := os.Remove(path); err != nil {
+			t.Fatal(err)
+		}
+	}
+	defer listen(replaced).Close()
+	if err := os.WriteFile(overwritten, []byte("keep me"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	removeSockets(owned)
+
+	if _, err := os.Lstat(ours); !os.IsNotExist(err) {
+		t.Errorf("Expected %s removed, got stat err=%v", ours, err)
+	}
+	for _, path := range []string{replaced, overwritten} {
+		if _, err := os.Lstat(path); err != nil {
+			t.Errorf("Expected %s to be left alone, got %v", path, err)
+		}
+	}
+}
+
+func TestLoadConfigAppliesWatchedLogLevel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"log_level": "info"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_FILE", path)
+	t.Cleanup(func() { logging.SetLevel(config.LogLevelInfo) })
+
+	live, stop, err := loadConfig()
+	if err != nil {
+		t.Fatalf("loadConfig() returned error: %v", err)
+	}
+	defer stop()
+	applyLogLevel(live.Load())
+
+	if err := os.WriteFile(path, []byte(`{"log_level": "debug"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for logging.Level() != slog.LevelDebug {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the watched log level to take effect, still %s", logging.Level())
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if live.Load().LogLevel != config.LogLevelDebug {
+		t.Errorf("Expected live config log level %q, got %q", config.LogLevelDebug, live.Load().LogLevel)
+	}
+}
+
+func TestWatchedAndSignalReloadsShareOneBaseline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"log_level": "info"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_FILE", path)
+	t.Cleanup(func() { logging.SetLevel(config.LogLevelInfo) })
+
+	live, stop, err := loadConfig()
+	if err != nil {
+		t.Fatalf("loadConfig() returned error: %v", err)
+	}
+	defer stop()
+
+	// A reload from another trigger moves the live config to warn
+	next := *live.Load()
+	next.LogLevel = config.LogLevelWarn
+	live.reload(&next, "SIGHUP")
+
+	// The file still says info; editing anything else in it must restore
+	// info, since the watcher diffs against the live config
+	if err := os.WriteFile(path, []byte(`{"log_level": "info", "port": 9999}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for live.Load().LogLevel != config.LogLevelInfo {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the file's log level to be reapplied, still %q", live.Load().LogLevel)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if got := logging.Level(); got != slog.LevelInfo {
+		t.Errorf("Expected the effective log level %s, got %s", slog.LevelInfo, got)
+	}
+	if got := live.Load().Port; got == 9999 {
+		t.Error("Expected the port change to wait for a restart")
+	}
+}
+
+func TestLiveConfigApplyReloadableFields(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Port = 8080
+	live := newLiveConfig(cfg)
+
+	next := *cfg
+	next.LogLevel = config.LogLevelDebug
+	next.Port = 9090
+
+	if !live.apply(&next) {
+		t.Fatal("Expected log level change to be applied")
+	}
+
+	current := live.Load()
+	if current.LogLevel != config.LogLevelDebug {
+		t.Errorf("Expected log level %q, got %q", config.LogLevelDebug, current.LogLevel)
+	}
+	if current.Port != 8080 {
+		t.Errorf("Expected port change to wait for a restart, got port %d", current.Port)
+	}
+	if cfg.LogLevel == config.LogLevelDebug {
+		t.Error("Expected the original config to be left unmodified")
+	}
+
+	portOnly := *current
+	portOnly.Port = 9090
+	if live.apply(&portOnly) {
+		t.Error("Expected a port-only change to be rejected")
+	}
+	if live.Load().Port != 8080 {
+		t.Errorf("Expected port to stay 8080, got %d", live.Load().Port)
+	}
+}
+
+func TestReloadOnSignal(t *testing.T) {
+	live := newLiveConfig(testConfig(t))
+	t.Setenv("LOG_LEVEL", "warn")
+	t.Cleanup(func() { logging.SetLevel(config.LogLevelInfo) })
+
+	sig := make(chan os.Signal)
+	done := make(chan struct{})
+	go func() {
+		reloadOnSignal(sig, live)
+		close(done)
+	}()
+
+	sig <- syscall.SIGHUP
+	close(sig)
+	<-done
+
+	if got := live.Load().LogLevel; got != config.LogLevelWarn {
+		t.Errorf("Expected SIGHUP to reload log level %q, got %q", config.LogLevelWarn, got)
+	}
+	if got := logging.Level(); got != slog.LevelWarn {
+		t.Errorf("Expected SIGHUP to make %s the effective log level, got %s", slog.LevelWarn, got)
+	}
+}
+
+func TestRunReportsPortInUse(t *testing.T) {
+	taken, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer taken.Close()
+
+	cfg := testConfig(t)
+	cfg.Port = taken.Addr().(*net.TCPAddr).Port
+
+	err = run(context.Background(), cfg)
+	if err == nil {
+		t.Fatal("Expected error when the port is already in use")
+	}
+	if !errors.Is(err, syscall.EADDRINUSE) {
+		t.Errorf("Expected EADDRINUSE to be wrapped, got %v", err)
+	}
+	for _, hint := range []string{fmt.Sprintf("port %d is already in use", cfg.Port), "PORT", "AUTO_PORT"} {
+		if !strings.Contains(err.Error(), hint) {
+			t.Errorf("Expected error to mention %q, got %v", hint, err)
+		}
+	}
+}
+
+func TestListenAllAutoPort(t *testing.T) {
+	taken, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer taken.Close()
+	port := taken.Addr().(*net.TCPAddr).Port
+
+	cfg := testConfig(t)
+	cfg.Port = port
+	cfg.AutoPort = true
+
+	listeners, err := listenAll(cfg)
+	if err != nil {
+		t.Fatalf("listenAll() returned error: %v", err)
+	}
+	defer closeAll(listeners)
+
+	got := listeners[0].Addr().(*net.TCPAddr).Port
+	if got <= port || got > port+autoPortAttempts {
+		t.Errorf("Expected a port in (%d, %d], got %d", port, port+autoPortAttempts, got)
+	}
+}
+
+func TestListenAllStaleSocket(t *testing.T) {
+	dir := t.TempDir()
+
+	// A socket left behind by a run that didn't clean up
+	stale := filepath.Join(dir, "stale.sock")
+	old, err := net.Listen("unix", stale)
+	if err != nil {
+		t.Fatal(err)
+	}
+	old.(*net.UnixListener).SetUnlinkOnClose(false)
+	old.Close()
+
+	cfg := testConfig(t)
+	cfg.ListenAddresses = []string{"unix:" + stale}
+	listeners, err := listenAll(cfg)
+	if err != nil {
+		t.Fatalf("Expected a stale socket to be replaced, got %v", err)
+	}
+	closeAll(listeners)
+}
+
+func TestListenAllRefusesToRemove(t *testing.T) {
+	dir := t.TempDir()
+
+	regular := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(regular, []byte("keep me"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	live := filepath.Join(dir, "live.sock")
+	other, err := net.Listen("unix", live)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+
+	tests := map[string]struct {
+		path string
+		want string
+	}{
+		"regular file":  {regular, "is not a socket"},
+		"socket in use": {live, "in use by another process"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := testConfig(t)
+			cfg.ListenAddresses = []string{"unix:" + tt.path}
+
+			listeners, err := listenAll(cfg)
+			if err == nil {
+				closeAll(listeners)
+				t.Fatal("Expected listenAll to refuse the path")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error to contain %q, got %v", tt.want, err)
+			}
+			if _, err := os.Lstat(tt.path); err != nil {
+				t.Errorf("Expected %s to be left in place, got %v", tt.path, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package main
+
+import "os"
+
+// awaitShutdown blocks until the first signal arrives on sigs and returns it
+// so the caller can log what initiated shutdown. Any further signal calls
+// forceExit, letting an operator hit Ctrl-C twice to skip the graceful wait.
+// Closing sigs releases the background watcher.
+func awaitShutdown(sigs <-chan os.Signal, forceExit func(os.Signal)) os.Signal {
+	sig := <-sigs
+
+	go func() {
+		if second, ok := <-sigs; ok {
+			forceExit(second)
+		}
+	}()
+
+	return sig
+}
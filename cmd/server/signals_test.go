@@ -0,0 +1,50 @@
+package main
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestAwaitShutdownReturnsFirstSignal(t *testing.T) {
+	sigs := make(chan os.Signal, 2)
+	defer close(sigs)
+
+	forced := make(chan os.Signal, 1)
+	sigs <- syscall.SIGTERM
+
+	sig := awaitShutdown(sigs, func(s os.Signal) { forced <- s })
+	if sig != syscall.SIGTERM {
+		t.Errorf("Expected SIGTERM, got %v", sig)
+	}
+
+	select {
+	case s := <-forced:
+		t.Errorf("Expected no forced exit after one signal, got %v", s)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestAwaitShutdownSecondSignalForcesExit(t *testing.T) {
+	sigs := make(chan os.Signal, 2)
+	defer close(sigs)
+
+	forced := make(chan os.Signal, 1)
+	sigs <- syscall.SIGINT
+
+	if sig := awaitShutdown(sigs, func(s os.Signal) { forced <- s }); sig != syscall.SIGINT {
+		t.Errorf("Expected SIGINT, got %v", sig)
+	}
+
+	sigs <- syscall.SIGINT
+
+	select {
+	case s := <-forced:
+		if s != syscall.SIGINT {
+			t.Errorf("Expected forced exit on SIGINT, got %v", s)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected second signal to force exit")
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/your-org/go-template-project/internal/handlers"
+)
+
+// staleTickIntervals is how many task intervals may pass without a loop tick
+// before the worker reports itself unhealthy.
+const staleTickIntervals = 3
+
+// WorkerHealthResponse extends the server's health response with worker
+// progress so probes can spot a hung processing loop.
+type WorkerHealthResponse struct {
+	handlers.HealthResponse
+	LastTick   time.Time `json:"last_tick"`
+	Processed  uint64    `json:"processed"`
+	Failed     uint64    `json:"failed"`
+	QueueDepth int       `json:"queue_depth"`
+	Dropped    uint64    `json:"dropped"`
+}
+
+// healthHandler reports worker liveness.
+//
+// GET /health
+//
+// Returns:
+//   - 200: Worker loop ticked recently
+//   - 503: Worker loop has not ticked within staleTickIntervals intervals
+func (w *Worker) healthHandler() http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			rw.Header().Set("Allow", "GET")
+			handlers.WriteError(rw, r, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+
+		now := w.clock.Now().UTC()
+		lastTick := w.LastTick()
+		stats := w.Stats()
+
+		response := WorkerHealthResponse{
+			HealthResponse: handlers.HealthResponse{
+				Status:    "healthy",
+				Timestamp: now,
+				Version:   appVersion,
+			},
+			LastTick:   lastTick,
+			Processed:  stats.Processed,
+			Failed:     stats.Failed,
+			QueueDepth: stats.QueueDepth,
+			Dropped:    stats.Dropped,
+		}
+		status := http.StatusOK
+
+		if since, ok := w.sinceLastTick(); !ok || since > staleTickIntervals*w.interval {
+			response.Status = "unhealthy"
+			status = http.StatusServiceUnavailable
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(status)
+
+		if err := json.NewEncoder(rw).Encode(response); err != nil {
+			// Error encoding response, but status already sent
+			return
+		}
+	}
+}
+
+// serveHealth starts the health endpoint on addr in the background, along
+// with /metrics for the worker's counters and queue gauges. The returned
+// server's Addr holds the bound address; shut it down with the worker.
+func (w *Worker) serveHealth(addr string) (*http.Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", w.healthHandler())
+	mux.HandleFunc("/metrics", handlers.Prometheus(w.metrics))
+
+	server := &http.Server{
+		Addr:              listener.Addr().String(),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Health endpoint error: %v", err)
+		}
+	}()
+
+	return server, nil
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/your-org/go-template-project/internal/config"
+)
+
+func TestWorkerHealthLastTickAdvances(t *testing.T) {
+	w := NewWorker(&config.Config{})
+	w.interval = 20 * time.Millisecond
+	w.handler = func(ctx context.Context) error { return nil }
+
+	server, err := w.serveHealth("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("serveHealth() returned error: %v", err)
+	}
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Start(ctx)
+
+	first := fetchWorkerHealth(t, "http://"+server.Addr+"/health")
+	if first.LastTick.IsZero() {
+		// The loop may not have recorded its first tick yet
+		time.Sleep(10 * time.Millisecond)
+		first = fetchWorkerHealth(t, "http://"+server.Addr+"/health")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		time.Sleep(w.interval)
+
+		current := fetchWorkerHealth(t, "http://"+server.Addr+"/health")
+		if current.Status != "healthy" {
+			t.Fatalf("Expected status 'healthy', got %s", current.Status)
+		}
+		if current.LastTick.After(first.LastTick) {
+			return
+		}
+	}
+	t.Errorf("Expected last tick to advance past %v", first.LastTick)
+}
+
+func TestWorkerHealthUnhealthyWhenStale(t *testing.T) {
+	clock := newFakeClock()
+	w := newWorkerWithClock(&config.Config{}, clock)
+	w.interval = time.Second
+
+	status := func() int {
+		rr := httptest.NewRecorder()
+		w.healthHandler()(rr, httptest.NewRequest(http.MethodGet, "/health", nil))
+		return rr.Code
+	}
+
+	if got := status(); got != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503 before the first tick, got %d", got)
+	}
+
+	w.tick()
+	if got := status(); got != http.StatusOK {
+		t.Errorf("Expected status 200 right after a tick, got %d", got)
+	}
+
+	// Wall-clock jumps either way don't change liveness
+	clock.Jump(time.Hour)
+	if got := status(); got != http.StatusOK {
+		t.Errorf("Expected a forward wall-clock jump to keep status 200, got %d", got)
+	}
+	clock.Jump(-2 * time.Hour)
+	clock.Advance(time.Minute)
+	if got := status(); got != http.StatusServiceUnavailable {
+		t.Errorf("Expected a stalled loop to report 503 despite a backward jump, got %d", got)
+	}
+}
+
+func TestWorkerMetricsExposeTaskCounters(t *testing.T) {
+	w := NewWorker(&config.Config{})
+
+	fail := func(ctx context.Context) error { return errors.New("task failed") }
+	noop := func(ctx context.Context) error { return nil }
+	for _, task := range []TaskHandler{noop, noop, fail} {
+		w.processTask(context.Background(), task)
+	}
+
+	server, err := w.serveHealth("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("serveHealth() returned error: %v", err)
+	}
+	defer server.Close()
+
+	resp, err := http.Get("http://" + server.Addr + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"worker_tasks_processed_total 2", "worker_tasks_failed_total 1"} {
+		if !strings.Contains(string(body), line+"\n") {
+			t.Errorf("Expected /metrics to contain %q, got:\n%s", line, body)
+		}
+	}
+}
+
+func TestWorkerMetricsExposeQueue(t *testing.T) {
+	w := NewWorker(&config.Config{WorkerQueueSize: 1, WorkerQueuePolicy: config.QueuePolicyDropOldest})
+	noop := func(ctx context.Context) error { return nil }
+
+	// Nothing consumes the queue until Start
+	for i := 0; i < 3; i++ {
+		if err := w.Submit(context.Background(), noop); err != nil {
+			t.Fatalf("Submit() returned error: %v", err)
+		}
+	}
+
+	server, err := w.serveHealth("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("serveHealth() returned error: %v", err)
+	}
+	defer server.Close()
+
+	resp, err := http.Get("http://" + server.Addr + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		"# TYPE worker_queue_depth gauge",
+		"worker_queue_depth 1",
+		"worker_tasks_dropped_total 2",
+	} {
+		if !strings.Contains(string(body), line+"\n") {
+			t.Errorf("Expected /metrics to contain %q, got:\n%s", line, body)
+		}
+	}
+}
+
+func fetchWorkerHealth(t *testing.T, url string) WorkerHealthResponse {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("Health request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var health WorkerHealthResponse
+	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
+		t.Fatalf("Failed to decode health response: %v", err)
+	}
+	return health
+}
@@ -2,13 +2,22 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"log/slog"
 	"os"
 	"os/signal"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
+	"github.com/your-org/go-template-project/internal/app"
 	"github.com/your-org/go-template-project/internal/config"
+	"github.com/your-org/go-template-project/internal/features"
+	"github.com/your-org/go-template-project/internal/logging"
+	"github.com/your-org/go-template-project/internal/metrics"
 )
 
 const (
@@ -16,32 +25,134 @@ const (
 	appVersion = "1.0.0"
 )
 
+// TaskHandler performs a single unit of background work. Handlers must return
+// promptly with ctx.Err() once ctx is cancelled so shutdown isn't held up.
+type TaskHandler func(ctx context.Context) error
+
+// simulatedWork is how long the default handler pretends to work.
+var simulatedWork = 100 * time.Millisecond
+
+// shutdownTimeout bounds how long run waits for the in-flight task to finish.
+const shutdownTimeout = 10 * time.Second
+
+// errWorkerStopped is returned by Submit once the worker is stopping.
+var errWorkerStopped = errors.New("worker is stopping")
+
+// Stats reports how many tasks the worker has run. Processed counts tasks that
+// completed successfully; Failed counts tasks whose handler returned an error.
+// QueueDepth is how many tasks are waiting to run and Dropped how many the
+// drop-oldest queue policy has discarded.
+type Stats struct {
+	Processed  uint64
+	Failed     uint64
+	QueueDepth int
+	Dropped    uint64
+}
+
 // Worker represents a background worker.
 type Worker struct {
-	config *config.Config
-	quit   chan bool
+	config    *config.Config
+	clock     clock
+	interval  time.Duration
+	handler   TaskHandler
+	queue     *taskQueue
+	quit      chan bool
+	stopOnce  sync.Once
+	done      chan struct{}
+	metrics   *metrics.Collector
+	processed *metrics.Counter
+	failed    *metrics.Counter
+	inFlight  atomic.Int64
+	lastTick  atomic.Int64 // unix nanoseconds of the last loop tick, for display
+
+	// lastTickElapsed is the clock's monotonic reading at the last loop
+	// tick, so staleness isn't fooled by wall-clock jumps.
+	lastTickElapsed atomic.Int64
 }
 
-// NewWorker creates a new worker instance.
+// NewWorker creates a new worker instance. A nil cfg uses config.Default().
 func NewWorker(cfg *config.Config) *Worker {
+	return newWorkerWithClock(cfg, newRealClock())
+}
+
+// newWorkerWithClock is NewWorker with the clock that drives scheduling, so
+// tests can advance time by hand.
+func newWorkerWithClock(cfg *config.Config, c clock) *Worker {
+	if cfg == nil {
+		cfg = config.Default()
+	}
+
+	collector := metrics.New()
+	queue := newTaskQueue(cfg.WorkerQueueSize, cfg.WorkerQueuePolicy,
+		collector.NewCounter("worker_tasks_dropped_total",
+			"Tasks the drop-oldest queue policy discarded."))
+	collector.NewGaugeFunc("worker_queue_depth", "Tasks waiting in the queue.",
+		func() float64 { return float64(queue.Len()) })
+
 	return &Worker{
-		config: cfg,
-		quit:   make(chan bool),
+		config:   cfg,
+		clock:    c,
+		interval: taskInterval(),
+		handler:  simulateTask,
+		queue:    queue,
+		metrics:  collector,
+		processed: collector.NewCounter("worker_tasks_processed_total",
+			"Tasks the worker completed successfully."),
+		failed: collector.NewCounter("worker_tasks_failed_total",
+			"Tasks whose handler returned an error."),
+		quit: make(chan bool),
+		done: make(chan struct{}),
 	}
 }
 
-// Start begins the worker processing loop.
+// defaultTaskInterval is how often the worker submits a task unless
+// WORKER_TASK_INTERVAL overrides it.
+const defaultTaskInterval = 10 * time.Second
+
+// taskInterval returns WORKER_TASK_INTERVAL, used to speed up tests, or
+// defaultTaskInterval when it is unset. Values that aren't a positive
+// duration are logged and ignored, since the schedule divides by the
+// interval.
+func taskInterval() time.Duration {
+	value := os.Getenv("WORKER_TASK_INTERVAL")
+	if value == "" {
+		return defaultTaskInterval
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("⚠️  Ignoring WORKER_TASK_INTERVAL=%q: must be a positive duration, using %s", value, defaultTaskInterval)
+		return defaultTaskInterval
+	}
+	return d
+}
+
+// Start begins the worker processing loop, which submits the handler to the
+// task queue every interval while a consumer runs queued tasks one at a time.
+// It returns once ctx is cancelled or Stop is called and the current task has
+// finished, closing Done. Tasks still queued then are discarded.
 func (w *Worker) Start(ctx context.Context) {
-	// Allow configuring task interval for testing
-	interval := 10 * time.Second
-	if testInterval := os.Getenv("WORKER_TASK_INTERVAL"); testInterval != "" {
-		if d, err := time.ParseDuration(testInterval); err == nil {
-			interval = d
+	defer close(w.done)
+
+	consumed := make(chan struct{})
+	go func() {
+		defer close(consumed)
+		w.consume(ctx)
+	}()
+	defer func() {
+		<-consumed
+		if n := w.queue.Len(); n > 0 {
+			log.Printf("⚠️  %d queued task(s) discarded", n)
 		}
-	}
+	}()
+
+	// Schedule from monotonic elapsed time so wall-clock jumps don't cause
+	// bursts of catch-up runs or stalls
+	sched := newSchedule(w.clock, w.interval)
+	timer := w.clock.NewTimer(sched.untilNext())
+	defer timer.Stop()
 
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
+	w.tick()
 
 	log.Printf("🚀 Worker %s v%s started", appName, appVersion)
 
@@ -53,59 +164,256 @@ func (w *Worker) Start(ctx context.Context) {
 		case <-w.quit:
 			log.Println("🛑 Worker quit signal received")
 			return
-		case <-ticker.C:
-			w.processTask()
+		case <-timer.C():
+			w.tick()
+			if w.stopping() {
+				log.Println("🛑 Worker quit signal received")
+				return
+			}
+			// Under the block policy this waits while the queue is full. It
+			// only fails once the worker is shutting down, which the next
+			// select picks up
+			if sched.due() {
+				_ = w.Submit(ctx, w.handler)
+			}
+			timer.Reset(sched.untilNext())
+		}
+	}
+}
+
+// consume runs queued tasks until ctx is cancelled or the worker is stopped,
+// never starting a new task once either has happened.
+func (w *Worker) consume(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.quit:
+			return
+		case task := <-w.queue.C():
+			if ctx.Err() != nil || w.stopping() {
+				return
+			}
+			if err := w.processTask(ctx, task); err != nil {
+				if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+					log.Println("🛑 Task interrupted by shutdown")
+				} else {
+					log.Printf("❌ Task failed: %v", err)
+				}
+			}
 		}
 	}
 }
 
-// Stop gracefully stops the worker.
+// Submit queues task to run on the worker. When the queue is full the
+// configured WorkerQueuePolicy applies: block waits for room until ctx is
+// done or the worker stops, drop-oldest discards the longest-waiting task.
+func (w *Worker) Submit(ctx context.Context, task TaskHandler) error {
+	if w.stopping() {
+		return errWorkerStopped
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-w.quit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	if err := w.queue.Push(ctx, task); err != nil {
+		if w.stopping() {
+			return errWorkerStopped
+		}
+		return err
+	}
+	return nil
+}
+
+// Stop gracefully stops the worker. It is safe to call more than once.
 func (w *Worker) Stop() {
-	close(w.quit)
+	w.stopOnce.Do(func() { close(w.quit) })
+}
+
+// stopping reports whether Stop has been called, so no new task starts once
+// the timer and quit signal race.
+func (w *Worker) stopping() bool {
+	select {
+	case <-w.quit:
+		return true
+	default:
+		return false
+	}
 }
 
-// processTask simulates processing a background task.
-func (w *Worker) processTask() {
+// Done returns a channel that is closed when Start returns.
+func (w *Worker) Done() <-chan struct{} {
+	return w.done
+}
+
+// Shutdown stops the worker and waits for Start to return or ctx to expire,
+// logging whether the worker drained. It returns ctx.Err() on timeout.
+func (w *Worker) Shutdown(ctx context.Context) error {
+	w.Stop()
+
+	select {
+	case <-w.done:
+		log.Println("✅ worker drained cleanly")
+		return nil
+	case <-ctx.Done():
+		log.Printf("⚠️  worker shutdown timed out, %d tasks may be incomplete", w.inFlight.Load())
+		return ctx.Err()
+	}
+}
+
+// tick records that the processing loop is still making progress.
+func (w *Worker) tick() {
+	w.lastTickElapsed.Store(int64(w.clock.Elapsed()))
+	w.lastTick.Store(w.clock.Now().UnixNano())
+}
+
+// sinceLastTick returns the monotonic time since the processing loop last
+// made progress, and false if it has not started.
+func (w *Worker) sinceLastTick() (time.Duration, bool) {
+	if w.lastTick.Load() == 0 {
+		return 0, false
+	}
+	return w.clock.Elapsed() - time.Duration(w.lastTickElapsed.Load()), true
+}
+
+// LastTick returns the wall-clock time the processing loop last made
+// progress, or the zero time if it has not started. It is for display;
+// liveness uses monotonic time.
+func (w *Worker) LastTick() time.Time {
+	nanos := w.lastTick.Load()
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos).UTC()
+}
+
+// Stats returns a snapshot of the worker's task counters.
+func (w *Worker) Stats() Stats {
+	return Stats{
+		Processed:  w.processed.Value(),
+		Failed:     w.failed.Value(),
+		QueueDepth: w.queue.Len(),
+		Dropped:    w.queue.Dropped(),
+	}
+}
+
+// processTask runs task once and records the outcome.
+func (w *Worker) processTask(ctx context.Context, task TaskHandler) error {
 	if w.config.Debug {
 		log.Println("📋 Processing task...")
 	}
 
-	// Simulate work
-	time.Sleep(100 * time.Millisecond)
+	w.inFlight.Add(1)
+	defer w.inFlight.Add(-1)
+
+	if err := task(ctx); err != nil {
+		w.failed.Inc()
+		return err
+	}
+	w.processed.Inc()
 
 	if w.config.Debug {
 		log.Println("✅ Task completed")
 	}
+	return nil
 }
 
-func main() {
-	cfg, err := config.Load()
-	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
+// simulateTask is the default handler, standing in for real work.
+func simulateTask(ctx context.Context) error {
+	timer := time.NewTimer(simulatedWork)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
 	}
+}
+
+// run starts the worker, plus the health endpoint when WORKER_HEALTH_PORT is
+// set, and blocks until ctx is cancelled. It then lets the in-flight task
+// finish for up to shutdownTimeout before returning. A nil cfg uses
+// config.Default().
+func run(ctx context.Context, cfg *config.Config) error {
+	if cfg == nil {
+		cfg = config.Default()
+	}
+	config.LogEffective(slog.Default(), appName, cfg)
 
 	worker := NewWorker(cfg)
 
-	// Create context for graceful shutdown
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	// Optional health endpoint for liveness probes
+	if port := os.Getenv("WORKER_HEALTH_PORT"); port != "" {
+		healthServer, err := worker.serveHealth(":" + port)
+		if err != nil {
+			return fmt.Errorf("failed to start health endpoint: %w", err)
+		}
+		log.Printf("🩺 Worker health endpoint listening on %s", healthServer.Addr)
 
-	// Start worker in goroutine
-	go worker.Start(ctx)
+		defer func() {
+			shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer shutdownCancel()
+			if err := healthServer.Shutdown(shutdownCtx); err != nil {
+				log.Printf("Health endpoint shutdown error: %v", err)
+			}
+		}()
+	}
 
-	// Wait for interrupt signal
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-	<-sigChan
+	// Tasks get their own context so cancelling ctx stops new work without
+	// interrupting the in-flight task; it is cancelled once draining ends
+	workCtx, cancelWork := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelWork()
 
-	log.Println("🛑 Shutting down worker...")
+	go worker.Start(workCtx)
 
-	// Stop worker gracefully
-	worker.Stop()
-	cancel()
+	<-ctx.Done()
+	log.Println("🛑 Shutting down worker...")
 
-	// Give worker time to finish current task
-	time.Sleep(2 * time.Second)
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer shutdownCancel()
+	if err := worker.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("worker did not drain within %s: %w", shutdownTimeout, err)
+	}
 
 	log.Println("✅ Worker shut down gracefully")
+	return nil
+}
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Printf("Failed to load config: %v", err)
+		os.Exit(app.ExitFailure)
+	}
+
+	if err := logging.Install(os.Stderr, cfg.LogLevel); err != nil {
+		log.Printf("Failed to set up logging: %v", err)
+		os.Exit(app.ExitFailure)
+	}
+
+	// Read FEATURE_* toggles once so malformed values are logged at startup
+	features.Refresh()
+	log.Printf("🚩 Feature flags enabled: %v", features.List())
+
+	// SIGUSR1 dumps every goroutine's stack to stderr to diagnose hangs
+	// (Unix only)
+	app.NotifyGoroutineDumps(os.Stderr)
+
+	// Cancel on SIGINT or SIGTERM to drain and shut down gracefully
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, cfg); err != nil {
+		log.Print(err)
+		stop()
+		os.Exit(app.ExitCode(err))
+	}
 }
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/your-org/go-template-project/internal/app"
+	"github.com/your-org/go-template-project/internal/config"
+)
+
+func TestWorkerStatsTracksFailures(t *testing.T) {
+	w := NewWorker(&config.Config{})
+
+	calls := 0
+	w.handler = func(ctx context.Context) error {
+		calls++
+		if calls%2 == 0 {
+			return errors.New("task failed")
+		}
+		return nil
+	}
+
+	for i := 0; i < 5; i++ {
+		err := w.processTask(context.Background(), w.handler)
+		if wantErr := (i+1)%2 == 0; (err != nil) != wantErr {
+			t.Errorf("Run %d: expected error=%t, got %v", i+1, wantErr, err)
+		}
+	}
+
+	stats := w.Stats()
+	if stats.Processed != 3 {
+		t.Errorf("Expected 3 processed tasks, got %d", stats.Processed)
+	}
+	if stats.Failed != 2 {
+		t.Errorf("Expected 2 failed tasks, got %d", stats.Failed)
+	}
+}
+
+func TestProcessTaskReturnsPromptlyOnCancel(t *testing.T) {
+	original := simulatedWork
+	simulatedWork = 5 * time.Second
+	defer func() { simulatedWork = original }()
+
+	w := NewWorker(&config.Config{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := w.processTask(ctx, w.handler)
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if elapsed > time.Second {
+		t.Errorf("Expected task to return promptly after cancel, took %v", elapsed)
+	}
+}
+
+func TestShutdownDrainsInFlightTask(t *testing.T) {
+	w := NewWorker(&config.Config{})
+	w.interval = 10 * time.Millisecond
+
+	started := make(chan struct{})
+	var once sync.Once
+	w.handler = func(ctx context.Context) error {
+		once.Do(func() { close(started) })
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	}
+
+	go w.Start(context.Background())
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := w.Shutdown(ctx); err != nil {
+		t.Fatalf("Expected worker to drain cleanly, got %v", err)
+	}
+	if n := w.inFlight.Load(); n != 0 {
+		t.Errorf("Expected no tasks in flight, got %d", n)
+	}
+	if stats := w.Stats(); stats.Processed == 0 || stats.Failed != 0 {
+		t.Errorf("Expected the in-flight task to complete, got %+v", stats)
+	}
+}
+
+func TestShutdownTimesOutWithTaskInFlight(t *testing.T) {
+	w := NewWorker(&config.Config{})
+	w.interval = 10 * time.Millisecond
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	var once sync.Once
+	w.handler = func(ctx context.Context) error {
+		once.Do(func() { close(started) })
+		<-release
+		return nil
+	}
+
+	go w.Start(context.Background())
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := w.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if n := w.inFlight.Load(); n != 1 {
+		t.Errorf("Expected 1 task in flight, got %d", n)
+	}
+
+	select {
+	case <-w.Done():
+		t.Error("Expected Start to still be running the task")
+	default:
+	}
+}
+
+func TestRunReturnsCleanlyOnCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- run(ctx, &config.Config{}) }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected a clean return, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected run to return promptly after cancellation")
+	}
+}
+
+func TestRunReportsHealthEndpointFailure(t *testing.T) {
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	t.Setenv("WORKER_HEALTH_PORT", port)
+
+	err = run(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "failed to start health endpoint") {
+		t.Errorf("Expected a health endpoint error, got %v", err)
+	}
+	if code := app.ExitCode(err); code != app.ExitFailure {
+		t.Errorf("Expected exit code %d, got %d", app.ExitFailure, code)
+	}
+}
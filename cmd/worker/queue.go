@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+
+	"github.com/your-org/go-template-project/internal/config"
+	"github.com/your-org/go-template-project/internal/metrics"
+)
+
+// taskQueue is a bounded buffer of tasks between producers and the worker
+// loop. What Push does when the queue is full depends on the policy: block
+// waits for room, drop-oldest discards the task that has waited longest.
+type taskQueue struct {
+	tasks   chan TaskHandler
+	policy  string
+	dropped *metrics.Counter
+}
+
+// newTaskQueue returns a queue holding up to size tasks that counts the
+// tasks it drops on dropped. Sizes below 1 are raised to 1 and unknown
+// policies block.
+func newTaskQueue(size int, policy string, dropped *metrics.Counter) *taskQueue {
+	return &taskQueue{
+		tasks:   make(chan TaskHandler, max(size, 1)),
+		policy:  policy,
+		dropped: dropped,
+	}
+}
+
+// Push adds task to the queue. Under the block policy it waits until there
+// is room or ctx is done, returning ctx.Err() in the latter case.
+func (q *taskQueue) Push(ctx context.Context, task TaskHandler) error {
+	if q.policy != config.QueuePolicyDropOldest {
+		select {
+		case q.tasks <- task:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	for {
+		select {
+		case q.tasks <- task:
+			return nil
+		default:
+		}
+
+		// Full: make room, unless the consumer just did
+		select {
+		case <-q.tasks:
+			q.dropped.Inc()
+		default:
+		}
+	}
+}
+
+// C returns the channel the consumer receives tasks from.
+func (q *taskQueue) C() <-chan TaskHandler {
+	return q.tasks
+}
+
+// Len returns how many tasks are waiting.
+func (q *taskQueue) Len() int {
+	return len(q.tasks)
+}
+
+// Dropped returns how many tasks the drop-oldest policy has discarded.
+func (q *taskQueue) Dropped() uint64 {
+	return q.dropped.Value()
+}
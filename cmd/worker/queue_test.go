@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/your-org/go-template-project/internal/config"
+	"github.com/your-org/go-template-project/internal/metrics"
+)
+
+// labelled returns a task that appends label to ran when it runs.
+func labelled(ran *[]string, label string) TaskHandler {
+	return func(ctx context.Context) error {
+		*ran = append(*ran, label)
+		return nil
+	}
+}
+
+// drain runs every queued task in order.
+func drain(q *taskQueue) {
+	for q.Len() > 0 {
+		(<-q.C())(context.Background())
+	}
+}
+
+func TestTaskQueueBlockPolicyWaitsForRoom(t *testing.T) {
+	q := newTaskQueue(1, config.QueuePolicyBlock, &metrics.Counter{})
+	var ran []string
+
+	if err := q.Push(context.Background(), labelled(&ran, "first")); err != nil {
+		t.Fatalf("Push() returned error: %v", err)
+	}
+
+	// A full queue blocks until the context gives up
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := q.Push(ctx, labelled(&ran, "timed out")); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded on a full queue, got %v", err)
+	}
+
+	// ...or until the consumer makes room
+	pushed := make(chan error, 1)
+	go func() { pushed <- q.Push(context.Background(), labelled(&ran, "second")) }()
+
+	select {
+	case err := <-pushed:
+		t.Fatalf("Expected Push to block on a full queue, returned %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	(<-q.C())(context.Background())
+	select {
+	case err := <-pushed:
+		if err != nil {
+			t.Fatalf("Push() returned error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected Push to finish once there was room")
+	}
+	drain(q)
+
+	if want := []string{"first", "second"}; !reflect.DeepEqual(ran, want) {
+		t.Errorf("Expected tasks %v, got %v", want, ran)
+	}
+	if q.Dropped() != 0 {
+		t.Errorf("Expected the block policy never to drop, got %d", q.Dropped())
+	}
+}
+
+func TestTaskQueueDropOldestPolicy(t *testing.T) {
+	q := newTaskQueue(2, config.QueuePolicyDropOldest, &metrics.Counter{})
+	var ran []string
+
+	for _, label := range []string{"a", "b", "c", "d"} {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		err := q.Push(ctx, labelled(&ran, label))
+		cancel()
+		if err != nil {
+			t.Fatalf("Push(%s) returned error: %v", label, err)
+		}
+	}
+
+	if q.Len() != 2 {
+		t.Errorf("Expected the queue to stay at its size of 2, got %d", q.Len())
+	}
+	if q.Dropped() != 2 {
+		t.Errorf("Expected 2 dropped tasks, got %d", q.Dropped())
+	}
+
+	drain(q)
+	if want := []string{"c", "d"}; !reflect.DeepEqual(ran, want) {
+		t.Errorf("Expected the newest tasks %v to survive, got %v", want, ran)
+	}
+}
+
+func TestWorkerStatsReportQueue(t *testing.T) {
+	w := NewWorker(&config.Config{WorkerQueueSize: 1, WorkerQueuePolicy: config.QueuePolicyDropOldest})
+	noop := func(ctx context.Context) error { return nil }
+
+	// Nothing consumes the queue until Start
+	for i := 0; i < 3; i++ {
+		if err := w.Submit(context.Background(), noop); err != nil {
+			t.Fatalf("Submit() returned error: %v", err)
+		}
+	}
+
+	if stats := w.Stats(); stats.QueueDepth != 1 || stats.Dropped != 2 {
+		t.Errorf("Expected queue depth 1 with 2 dropped, got %+v", stats)
+	}
+}
+
+func TestWorkerSubmitUnblocksOnStop(t *testing.T) {
+	w := NewWorker(&config.Config{WorkerQueueSize: 1, WorkerQueuePolicy: config.QueuePolicyBlock})
+	noop := func(ctx context.Context) error { return nil }
+
+	if err := w.Submit(context.Background(), noop); err != nil {
+		t.Fatalf("Submit() returned error: %v", err)
+	}
+
+	submitted := make(chan error, 1)
+	go func() { submitted <- w.Submit(context.Background(), noop) }()
+
+	w.Stop()
+	select {
+	case err := <-submitted:
+		if !errors.Is(err, errWorkerStopped) {
+			t.Errorf("Expected errWorkerStopped, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected a blocked Submit to return once the worker stops")
+	}
+
+	if err := w.Submit(context.Background(), noop); !errors.Is(err, errWorkerStopped) {
+		t.Errorf("Expected Submit after Stop to fail, got %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import "time"
+
+// clock provides the time sources used for task scheduling.
+type clock interface {
+	// Now returns the current wall-clock time. It is only used for reporting.
+	Now() time.Time
+
+	// Elapsed returns the monotonic time elapsed since the clock was created.
+	// Unlike wall-clock time it never jumps, e.g. on NTP corrections.
+	Elapsed() time.Duration
+
+	// NewTimer returns a timer that fires once d has elapsed on this clock.
+	NewTimer(d time.Duration) timer
+}
+
+// timer is the subset of *time.Timer the worker loop uses.
+type timer interface {
+	C() <-chan time.Time
+	Reset(d time.Duration)
+	Stop()
+}
+
+// realClock reads the system clock.
+type realClock struct {
+	start time.Time
+}
+
+func newRealClock() realClock {
+	return realClock{start: time.Now()}
+}
+
+func (c realClock) Now() time.Time { return time.Now() }
+
+// Elapsed uses time.Since, which subtracts monotonic clock readings.
+func (c realClock) Elapsed() time.Duration { return time.Since(c.start) }
+
+func (c realClock) NewTimer(d time.Duration) timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+// realTimer adapts *time.Timer to timer.
+type realTimer struct {
+	t *time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time   { return t.t.C }
+func (t realTimer) Reset(d time.Duration) { t.t.Reset(d) }
+func (t realTimer) Stop()                 { t.t.Stop() }
+
+// schedule decides when the next task is due using only monotonic elapsed
+// time. Wall-clock jumps therefore can't cause a burst of catch-up runs or a
+// stall, and intervals missed while the process was busy are skipped rather
+// than replayed.
+type schedule struct {
+	clock    clock
+	start    time.Duration
+	interval time.Duration
+	runs     int64
+}
+
+func newSchedule(c clock, interval time.Duration) *schedule {
+	return &schedule{
+		clock:    c,
+		start:    c.Elapsed(),
+		interval: interval,
+	}
+}
+
+// untilNext returns how long to wait before the next run is due.
+func (s *schedule) untilNext() time.Duration {
+	next := time.Duration(s.runs+1) * s.interval
+	wait := next - (s.clock.Elapsed() - s.start)
+	if wait < 0 {
+		return 0
+	}
+	return wait
+}
+
+// due reports whether a run is due and, if so, records it.
+func (s *schedule) due() bool {
+	completed := int64((s.clock.Elapsed() - s.start) / s.interval)
+	if completed <= s.runs {
+		return false
+	}
+	s.runs = completed
+	return true
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock tracks wall-clock and monotonic time separately so tests can
+// simulate wall-clock jumps that don't affect monotonic time. Its timers
+// only fire when Advance moves time past their deadline.
+type fakeClock struct {
+	mu     sync.Mutex
+	wall   time.Time
+	mono   time.Duration
+	timers []*fakeTimer
+
+	// armed receives a value each time a timer is started or reset, so tests
+	// know the worker loop is waiting before they advance time.
+	armed chan struct{}
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{
+		wall:  time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		armed: make(chan struct{}, 16),
+	}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.wall
+}
+
+func (c *fakeClock) Elapsed() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.mono
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) timer {
+	t := &fakeTimer{clock: c, c: make(chan time.Time, 1)}
+	c.mu.Lock()
+	c.timers = append(c.timers, t)
+	c.mu.Unlock()
+	t.Reset(d)
+	return t
+}
+
+// Advance moves both wall-clock and monotonic time forward, firing any
+// timers that come due.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.wall = c.wall.Add(d)
+	c.mono += d
+	for _, t := range c.timers {
+		t.fireIfDue()
+	}
+}
+
+// Jump moves only the wall clock, like an NTP correction.
+func (c *fakeClock) Jump(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.wall = c.wall.Add(d)
+}
+
+// fakeTimer is a timer driven by a fakeClock. Its fields are guarded by the
+// clock's mutex.
+type fakeTimer struct {
+	clock    *fakeClock
+	c        chan time.Time
+	deadline time.Duration
+	active   bool
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.c }
+
+func (t *fakeTimer) Reset(d time.Duration) {
+	t.clock.mu.Lock()
+	t.deadline = t.clock.mono + d
+	t.active = true
+	t.fireIfDue()
+	t.clock.mu.Unlock()
+
+	select {
+	case t.clock.armed <- struct{}{}:
+	default:
+	}
+}
+
+func (t *fakeTimer) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.active = false
+}
+
+// fireIfDue delivers a tick once the deadline has passed. The caller must
+// hold the clock's mutex.
+func (t *fakeTimer) fireIfDue() {
+	if !t.active || t.clock.mono < t.deadline {
+		return
+	}
+	t.active = false
+	select {
+	case t.c <- t.clock.wall:
+	default:
+	}
+}
+
+func TestScheduleRunsEachInterval(t *testing.T) {
+	clock := newFakeClock()
+	s := newSchedule(clock, 10*time.Second)
+
+	if s.due() {
+		t.Fatal("Expected no run before the first interval")
+	}
+
+	for i := 0; i < 3; i++ {
+		clock.Advance(5 * time.Second)
+		if s.due() {
+			t.Fatalf("Run %d: expected no run half-way through the interval", i)
+		}
+
+		clock.Advance(5 * time.Second)
+		if !s.due() {
+			t.Fatalf("Run %d: expected run at the interval boundary", i)
+		}
+	}
+}
+
+func TestScheduleStableAcrossBackwardJump(t *testing.T) {
+	clock := newFakeClock()
+	s := newSchedule(clock, 10*time.Second)
+
+	clock.Advance(4 * time.Second)
+	before := s.untilNext()
+
+	// NTP correction sets the wall clock back an hour
+	clock.Jump(-time.Hour)
+
+	if after := s.untilNext(); after != before {
+		t.Errorf("Expected wait to be unaffected by the jump: before %v, after %v", before, after)
+	}
+	if s.due() {
+		t.Error("Expected no run immediately after the jump")
+	}
+
+	clock.Advance(6 * time.Second)
+	if !s.due() {
+		t.Error("Expected run at the normal interval after the jump")
+	}
+}
+
+func TestScheduleNoBurstAfterForwardJump(t *testing.T) {
+	clock := newFakeClock()
+	s := newSchedule(clock, 10*time.Second)
+
+	// Wall clock jumps forward a day; no intervals have actually elapsed
+	clock.Jump(24 * time.Hour)
+	if s.due() {
+		t.Error("Expected no catch-up run after a forward wall-clock jump")
+	}
+}
+
+func TestScheduleSkipsMissedIntervals(t *testing.T) {
+	clock := newFakeClock()
+	s := newSchedule(clock, 10*time.Second)
+
+	// The process was busy for five intervals
+	clock.Advance(55 * time.Second)
+
+	runs := 0
+	for s.due() {
+		runs++
+	}
+	if runs != 1 {
+		t.Errorf("Expected a single run after missed intervals, got %d", runs)
+	}
+
+	if wait := s.untilNext(); wait != 5*time.Second {
+		t.Errorf("Expected next run aligned to the interval in 5s, got %v", wait)
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/your-org/go-template-project/internal/config"
+)
+
+// newTestWorker returns a worker ticking every few milliseconds whose handler
+// signals runs on the returned channel.
+func newTestWorker() (*Worker, <-chan struct{}) {
+	w := NewWorker(&config.Config{})
+	w.interval = 2 * time.Millisecond
+
+	runs := make(chan struct{}, 100)
+	w.handler = func(ctx context.Context) error {
+		select {
+		case runs <- struct{}{}:
+		default:
+		}
+		return nil
+	}
+	return w, runs
+}
+
+// waitDone fails the test unless the worker's loop exits within a second.
+func waitDone(t *testing.T, w *Worker) {
+	t.Helper()
+
+	select {
+	case <-w.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Expected worker loop to exit promptly")
+	}
+}
+
+func TestNewWorkerNilConfigUsesDefault(t *testing.T) {
+	w := NewWorker(nil)
+
+	if w.config == nil {
+		t.Fatal("Expected a nil config to fall back to config.Default()")
+	}
+	if w.config.Debug != config.Default().Debug {
+		t.Errorf("Expected default Debug %t, got %t", config.Default().Debug, w.config.Debug)
+	}
+
+	// The loop reads the config on every tick
+	w.interval = 2 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	w.Start(ctx)
+}
+
+func TestTaskInterval(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", defaultTaskInterval},
+		{"2s", 2 * time.Second},
+		{"0s", defaultTaskInterval},
+		{"-1s", defaultTaskInterval},
+		{"soon", defaultTaskInterval},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("WORKER_TASK_INTERVAL", tt.value)
+		if got := taskInterval(); got != tt.want {
+			t.Errorf("taskInterval() with %q = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestWorkerRunsTaskOnTick(t *testing.T) {
+	w, runs := newTestWorker()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Start(ctx)
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-runs:
+		case <-time.After(time.Second):
+			t.Fatalf("Expected task run %d to fire on tick", i+1)
+		}
+	}
+
+	if stats := w.Stats(); stats.Processed < 3 {
+		t.Errorf("Expected at least 3 processed tasks, got %d", stats.Processed)
+	}
+	if w.LastTick().IsZero() {
+		t.Error("Expected the loop to record a tick")
+	}
+}
+
+func TestWorkerStopExitsLoop(t *testing.T) {
+	w, runs := newTestWorker()
+
+	go w.Start(context.Background())
+	<-runs
+
+	w.Stop()
+	waitDone(t, w)
+
+	// Stop is idempotent
+	w.Stop()
+}
+
+func TestWorkerExitsOnContextCancel(t *testing.T) {
+	w, runs := newTestWorker()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go w.Start(ctx)
+	<-runs
+
+	cancel()
+	waitDone(t, w)
+}
+
+func TestWorkerFakeClockDrivesExactTicks(t *testing.T) {
+	clock := newFakeClock()
+	w := newWorkerWithClock(&config.Config{}, clock)
+	w.interval = 10 * time.Second
+
+	runs := make(chan struct{}, 10)
+	w.handler = func(ctx context.Context) error {
+		runs <- struct{}{}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Start(ctx)
+
+	for i := 0; i < 3; i++ {
+		// Wait for the loop to arm its timer, then move time one interval on
+		<-clock.armed
+		clock.Advance(w.interval)
+
+		select {
+		case <-runs:
+		case <-time.After(time.Second):
+			t.Fatalf("Expected task run %d after advancing the clock", i+1)
+		}
+	}
+
+	// The loop re-arms for the fourth interval but nothing fires without
+	// another Advance
+	<-clock.armed
+	select {
+	case <-runs:
+		t.Error("Expected exactly three runs")
+	default:
+	}
+
+	if stats := w.Stats(); stats.Processed != 3 {
+		t.Errorf("Expected 3 processed tasks, got %d", stats.Processed)
+	}
+	if want := time.Date(2024, 1, 1, 12, 0, 30, 0, time.UTC); !w.LastTick().Equal(want) {
+		t.Errorf("Expected last tick at %v, got %v", want, w.LastTick())
+	}
+
+	cancel()
+	waitDone(t, w)
+}
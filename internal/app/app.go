@@ -1,34 +1,81 @@
 package app
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
-	"os"
+	"runtime/debug"
+
+	"github.com/your-org/go-template-project/internal/env"
 )
 
+// Command is a named unit of CLI work. Long-running commands should return
+// ctx.Err() once ctx is cancelled.
+type Command func(ctx context.Context, args []string) error
+
 // App represents the core application.
 type App struct {
 	Name    string
 	Version string
 	Debug   bool
+
+	// Args selects a registered command (Args[0]) and its arguments. With no
+	// Args, Run prints the default greeting.
+	Args []string
+
+	commands map[string]Command
 }
 
-// New creates a new application instance.
+// New creates a new application instance. DEBUG is parsed like the rest of
+// the configuration; an unrecognized value is logged and leaves debug off.
 func New(name, version string) *App {
+	debugMode, err := env.Bool("DEBUG", false)
+	if err != nil {
+		log.Printf("Ignoring %v", err)
+	}
+
 	return &App{
-		Name:    name,
-		Version: version,
-		Debug:   os.Getenv("DEBUG") == "true",
+		Name:     name,
+		Version:  version,
+		Debug:    debugMode,
+		commands: make(map[string]Command),
 	}
 }
 
+// Register adds a command that can be selected through Args.
+func (a *App) Register(name string, cmd Command) {
+	a.commands[name] = cmd
+}
+
 // Run is the main entry point for CLI applications.
 // Separated from main() to make testing easier.
 func (a *App) Run() error {
+	return a.RunContext(context.Background())
+}
+
+// RunContext is Run with a context that cancels long-running commands,
+// typically on SIGINT. Pass the returned error to ExitCode for the process
+// exit status; an unknown command is a usage error and a panicking command
+// fails like one returning an error.
+func (a *App) RunContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	if a.Debug {
 		log.Printf("Starting %s v%s in debug mode", a.Name, a.Version)
 	}
 
+	if len(a.Args) > 0 {
+		cmd, ok := a.commands[a.Args[0]]
+		if !ok {
+			return UsageError("unknown command %q", a.Args[0])
+		}
+		return a.runCommand(ctx, a.Args[0], cmd, a.Args[1:])
+	}
+
 	fmt.Printf("🚀 Hello from %s!\n", a.Name)
 	fmt.Printf("   Version: %s\n", a.Version)
 
@@ -39,6 +86,21 @@ func (a *App) Run() error {
 	return nil
 }
 
+// runCommand calls cmd, recovering a panic into an error so the exit code
+// mapping still applies. In debug mode the error includes the stack trace.
+func (a *App) runCommand(ctx context.Context, name string, cmd Command, args []string) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("command %q panicked: %v", name, rec)
+			if a.Debug {
+				err = fmt.Errorf("%w\n%s", err, debug.Stack())
+			}
+		}
+	}()
+
+	return cmd(ctx, args)
+}
+
 // GetInfo returns basic application information.
 func (a *App) GetInfo() map[string]string {
 	return map[string]string{
@@ -47,3 +109,16 @@ func (a *App) GetInfo() map[string]string {
 		"debug":   fmt.Sprintf("%t", a.Debug),
 	}
 }
+
+// WriteJSON writes GetInfo merged with build metadata as a JSON object, for
+// tools that consume the CLI's output.
+func (a *App) WriteJSON(w io.Writer) error {
+	info := a.GetInfo()
+	for key, value := range ReadBuildInfo().Fields() {
+		info[key] = value
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(info)
+}
@@ -1,8 +1,15 @@
 package app
 
 import (
-	"os"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNew(t *testing.T) {
@@ -27,20 +34,17 @@ func TestRun(t *testing.T) {
 }
 
 func TestDebugMode(t *testing.T) {
-	// Test debug mode enabled
-	os.Setenv("DEBUG", "true")
-	defer os.Unsetenv("DEBUG")
+	for value, want := range map[string]bool{
+		"true": true, "1": true, "yes": true, "ON": true,
+		"false": false, "0": false, "no": false, "Off": false,
+		"maybe": false,
+	} {
+		t.Setenv("DEBUG", value)
 
-	app := New("test-app", "1.0.0")
-	if !app.Debug {
-		t.Error("Expected debug mode to be enabled")
-	}
-
-	// Test debug mode disabled
-	os.Setenv("DEBUG", "false")
-	app = New("test-app", "1.0.0")
-	if app.Debug {
-		t.Error("Expected debug mode to be disabled")
+		app := New("test-app", "1.0.0")
+		if app.Debug != want {
+			t.Errorf("Expected debug %t for DEBUG=%q, got %t", want, value, app.Debug)
+		}
 	}
 }
 
@@ -63,3 +67,131 @@ func TestGetInfo(t *testing.T) {
 		t.Errorf("Expected version '1.0.0', got '%s'", info["version"])
 	}
 }
+
+func TestRunContextCancelsCommand(t *testing.T) {
+	app := New("test-app", "1.0.0")
+	app.Register("wait", func(ctx context.Context, args []string) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(5 * time.Second):
+			return nil
+		}
+	})
+	app.Args = []string{"wait"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := app.RunContext(ctx)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected command to return promptly after cancel, took %v", elapsed)
+	}
+}
+
+func TestRunContextUnknownCommand(t *testing.T) {
+	app := New("test-app", "1.0.0")
+	app.Args = []string{"missing"}
+
+	err := app.RunContext(context.Background())
+	if err == nil {
+		t.Fatal("Expected error for unknown command")
+	}
+	if code := ExitCode(err); code != ExitUsage {
+		t.Errorf("Expected exit code %d for unknown command, got %d", ExitUsage, code)
+	}
+}
+
+func TestRunContextRecoversPanickingCommand(t *testing.T) {
+	for _, debug := range []bool{false, true} {
+		t.Run(fmt.Sprintf("debug=%t", debug), func(t *testing.T) {
+			app := New("test-app", "1.0.0")
+			app.Debug = debug
+			app.Args = []string{"boom"}
+			app.Register("boom", func(ctx context.Context, args []string) error {
+				panic("something broke")
+			})
+
+			err := app.RunContext(context.Background())
+			if err == nil {
+				t.Fatal("Expected panicking command to return an error")
+			}
+			if !strings.Contains(err.Error(), `command "boom" panicked: something broke`) {
+				t.Errorf("Expected error to describe the panic, got: %v", err)
+			}
+			if code := ExitCode(err); code != ExitFailure {
+				t.Errorf("Expected exit code %d for panic, got %d", ExitFailure, code)
+			}
+
+			// Only debug mode carries the stack trace
+			if hasStack := strings.Contains(err.Error(), "goroutine "); hasStack != debug {
+				t.Errorf("Expected stack trace present=%t, got error: %v", debug, err)
+			}
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitOK},
+		{"runtime error", errors.New("connection refused"), ExitFailure},
+		{"cancelled", context.Canceled, ExitFailure},
+		{"usage error", UsageError("unknown command %q", "missing"), ExitUsage},
+		{"wrapped usage error", fmt.Errorf("healthcheck: %w", UsageError("bad flag")), ExitUsage},
+		{"custom code", &ExitError{Code: 3, Err: errors.New("degraded")}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("Expected exit code %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	app := New("test-app", "1.0.0")
+
+	var buf bytes.Buffer
+	if err := app.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() returned error: %v", err)
+	}
+
+	var info map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &info); err != nil {
+		t.Fatalf("Failed to unmarshal output: %v", err)
+	}
+
+	if info["name"] != "test-app" {
+		t.Errorf("Expected name 'test-app', got '%s'", info["name"])
+	}
+	if info["version"] != "1.0.0" {
+		t.Errorf("Expected version '1.0.0', got '%s'", info["version"])
+	}
+	for _, field := range []string{"go_version", "os", "arch"} {
+		if info[field] == "" {
+			t.Errorf("Expected %s build metadata", field)
+		}
+	}
+}
+
+func TestReadBuildInfoPlatform(t *testing.T) {
+	info := ReadBuildInfo()
+
+	if info.GoVersion == "" || info.OS == "" || info.Arch == "" {
+		t.Fatalf("Expected Go version and platform to be set, got %+v", info)
+	}
+	if want := runtime.GOOS + "/" + runtime.GOARCH; info.Platform() != want {
+		t.Errorf("Expected platform %s, got %s", want, info.Platform())
+	}
+}
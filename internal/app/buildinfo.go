@@ -0,0 +1,69 @@
+package app
+
+import (
+	"runtime"
+	"runtime/debug"
+	"strconv"
+)
+
+// BuildInfo describes how the running binary was built. OS and Arch identify
+// the platform variant, e.g. linux/arm64, in fleets mixing architectures.
+type BuildInfo struct {
+	GoVersion string
+	OS        string
+	Arch      string
+	Revision  string
+	Time      string
+	Modified  bool
+}
+
+// ReadBuildInfo collects build metadata embedded by the Go toolchain. VCS
+// fields are empty when the binary was built outside a repository or via
+// `go run`.
+func ReadBuildInfo() BuildInfo {
+	info := BuildInfo{
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+
+	for _, setting := range bi.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.Revision = setting.Value
+		case "vcs.time":
+			info.Time = setting.Value
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+
+	return info
+}
+
+// Platform returns the OS/architecture pair, e.g. "linux/amd64".
+func (b BuildInfo) Platform() string {
+	return b.OS + "/" + b.Arch
+}
+
+// Fields returns the non-empty build metadata as string key/value pairs.
+func (b BuildInfo) Fields() map[string]string {
+	fields := map[string]string{
+		"go_version": b.GoVersion,
+		"os":         b.OS,
+		"arch":       b.Arch,
+	}
+	if b.Revision != "" {
+		fields["vcs_revision"] = b.Revision
+		fields["vcs_modified"] = strconv.FormatBool(b.Modified)
+	}
+	if b.Time != "" {
+		fields["vcs_time"] = b.Time
+	}
+	return fields
+}
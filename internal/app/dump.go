@@ -0,0 +1,29 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"runtime/pprof"
+	"time"
+)
+
+// DumpGoroutines writes the stack of every goroutine to w, in the same
+// format as an unrecovered panic.
+func DumpGoroutines(w io.Writer) error {
+	return pprof.Lookup("goroutine").WriteTo(w, 2)
+}
+
+// DumpGoroutinesOnSignal writes a goroutine dump to w each time a signal
+// arrives on sig, until sig is closed. Services wire it to SIGUSR1 with
+// NotifyGoroutineDumps so a hung process can be inspected without attaching
+// a debugger.
+func DumpGoroutinesOnSignal(sig <-chan os.Signal, w io.Writer) {
+	for s := range sig {
+		fmt.Fprintf(w, "=== goroutine dump on %s at %s ===\n", s, time.Now().UTC().Format(time.RFC3339))
+		if err := DumpGoroutines(w); err != nil {
+			log.Printf("Failed to dump goroutines: %v", err)
+		}
+	}
+}
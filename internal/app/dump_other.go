@@ -0,0 +1,8 @@
+//go:build !unix
+
+package app
+
+import "io"
+
+// NotifyGoroutineDumps does nothing on this platform, which has no SIGUSR1.
+func NotifyGoroutineDumps(w io.Writer) {}
@@ -0,0 +1,19 @@
+package app
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDumpGoroutines(t *testing.T) {
+	var buf bytes.Buffer
+	if err := DumpGoroutines(&buf); err != nil {
+		t.Fatalf("DumpGoroutines() returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "goroutine ") || !strings.Contains(out, "TestDumpGoroutines") {
+		t.Errorf("Expected stacks including this test, got:\n%s", out)
+	}
+}
@@ -0,0 +1,18 @@
+//go:build unix
+
+package app
+
+import (
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// NotifyGoroutineDumps writes a goroutine dump to w each time the process
+// receives SIGUSR1 (see DumpGoroutinesOnSignal).
+func NotifyGoroutineDumps(w io.Writer) {
+	usr1 := make(chan os.Signal, 1)
+	signal.Notify(usr1, syscall.SIGUSR1)
+	go DumpGoroutinesOnSignal(usr1, w)
+}
@@ -0,0 +1,55 @@
+//go:build unix
+
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestDumpGoroutinesOnSignal(t *testing.T) {
+	sig := make(chan os.Signal, 1)
+	sig <- syscall.SIGUSR1
+	close(sig)
+
+	var buf bytes.Buffer
+	DumpGoroutinesOnSignal(sig, &buf)
+
+	if !strings.Contains(buf.String(), "=== goroutine dump on user defined signal 1") {
+		t.Errorf("Expected a dump header naming the signal, got:\n%s", buf.String())
+	}
+	if !strings.Contains(buf.String(), "goroutine ") {
+		t.Error("Expected the dump to include goroutine stacks")
+	}
+}
+
+func TestNotifyGoroutineDumps(t *testing.T) {
+	r, w := io.Pipe()
+	defer r.Close()
+	NotifyGoroutineDumps(w)
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+
+	header := make(chan string, 1)
+	go func() {
+		line, _ := bufio.NewReader(r).ReadString('\n')
+		header <- line
+	}()
+
+	select {
+	case line := <-header:
+		if !strings.HasPrefix(line, "=== goroutine dump on user defined signal 1") {
+			t.Errorf("Expected a dump header, got %q", line)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("No goroutine dump after SIGUSR1")
+	}
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Process exit codes returned by ExitCode.
+const (
+	ExitOK      = 0
+	ExitFailure = 1
+	ExitUsage   = 2
+)
+
+// ExitError is an error that carries the process exit code for it.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// UsageError reports a problem with how the CLI was invoked, such as an
+// unknown command or bad flags. It exits with ExitUsage.
+func UsageError(format string, args ...any) error {
+	return &ExitError{Code: ExitUsage, Err: fmt.Errorf(format, args...)}
+}
+
+// ExitCode maps err to a process exit code: ExitOK for nil, the code of
+// any wrapped ExitError, and ExitFailure for every other error.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return ExitFailure
+}
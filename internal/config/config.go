@@ -2,70 +2,597 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"net/textproto"
+	"net/url"
 	"os"
+	"reflect"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/your-org/go-template-project/internal/env"
+)
+
+// Access log modes for AccessLogMode.
+const (
+	AccessLogAll    = "all"
+	AccessLogErrors = "errors"
+	AccessLogNone   = "none"
+)
+
+// Log levels for LogLevel.
+const (
+	LogLevelDebug = "debug"
+	LogLevelInfo  = "info"
+	LogLevelWarn  = "warn"
+	LogLevelError = "error"
+)
+
+// Worker queue policies for WorkerQueuePolicy.
+const (
+	QueuePolicyBlock      = "block"
+	QueuePolicyDropOldest = "drop-oldest"
 )
 
 // Config holds application configuration.
+//
+// Fields tagged `reload:"hot"` take effect when the server reloads its
+// configuration, on SIGHUP or a watched config file change (see
+// ApplyHotReload); the rest require a restart. Only tag a field hot once the
+// server actually applies it on reload.
 type Config struct {
 	Port         int           `json:"port"`
 	Host         string        `json:"host"`
 	Debug        bool          `json:"debug"`
 	ReadTimeout  time.Duration `json:"read_timeout"`
 	WriteTimeout time.Duration `json:"write_timeout"`
-	DatabaseURL  string        `json:"database_url,omitempty"`
+	IdleTimeout  time.Duration `json:"idle_timeout"`
+	DatabaseURL  string        `json:"database_url,omitempty" secret:"true"`
+	TLSCertFile  string        `json:"tls_cert_file,omitempty"`
+	TLSKeyFile   string        `json:"tls_key_file,omitempty"`
+
+	// AutoPort makes the server try the next few ports when Port is taken.
+	AutoPort bool `json:"auto_port"`
+
+	// BasePath mounts every server route under a prefix, e.g. /myservice
+	// when an ingress forwards /myservice/* to the app; empty serves from /.
+	BasePath string `json:"base_path,omitempty"`
+
+	// ListenAddresses, when set, replaces Address with one listener per
+	// entry. Entries are host:port pairs or unix:/path/to.sock;
+	// MaxConnections applies to each listener separately.
+	ListenAddresses []string `json:"listen_addresses,omitempty"`
+
+	// AdminAPIKey guards admin endpoints such as /admin/maintenance; empty
+	// disables them.
+	AdminAPIKey string `json:"-" secret:"true"`
+
+	// HandlerTimeout bounds how long a request handler may run; 0 disables it.
+	HandlerTimeout time.Duration `json:"handler_timeout"`
+
+	// MaxHeaderBytes limits the size of request headers the server will read.
+	MaxHeaderBytes int `json:"max_header_bytes"`
+
+	// MaxConnections caps simultaneous server connections; 0 means unlimited.
+	MaxConnections int `json:"max_connections"`
+
+	// KeepAlives lets clients reuse connections between requests; idle
+	// ones are closed after IdleTimeout.
+	KeepAlives bool `json:"keep_alives"`
+
+	// ReadinessConcurrency bounds how many readiness checks run at once.
+	ReadinessConcurrency int `json:"readiness_concurrency"`
+
+	// PreStopDelay is how long the server keeps serving with /ready failing
+	// after a shutdown signal, so load balancers stop routing to it before
+	// its listeners close; 0 shuts down immediately.
+	PreStopDelay time.Duration `json:"prestop_delay"`
+
+	// ReadinessTimeout caps how long /ready waits for all checks together;
+	// 0 disables it.
+	ReadinessTimeout time.Duration `json:"readiness_timeout"`
+
+	// DiskCheckPath, when set, makes /ready fail while the volume holding it
+	// is read-only or has less than DiskCheckMinFreeBytes available.
+	DiskCheckPath         string `json:"disk_check_path,omitempty"`
+	DiskCheckMinFreeBytes int64  `json:"disk_check_min_free_bytes"`
+
+	// CORSAllowedOrigins lists the origins (scheme://host[:port]) allowed
+	// to make cross-origin requests; "*" allows any.
+	CORSAllowedOrigins []string `json:"cors_allowed_origins,omitempty"`
+
+	// DependencyURLs are upstream HTTP endpoints that must answer 2xx for
+	// the server to report ready.
+	DependencyURLs []string `json:"dependency_urls,omitempty"`
+
+	// MetricsBuckets are the request latency histogram bounds in seconds;
+	// empty uses the metrics package defaults.
+	MetricsBuckets []float64 `json:"metrics_buckets,omitempty"`
+
+	// DisableInfoEndpoint removes /api/info from the server.
+	DisableInfoEndpoint bool `json:"disable_info_endpoint"`
+
+	// EnableMetrics records request metrics and serves /metrics and
+	// /metrics.json; false removes both.
+	EnableMetrics bool `json:"enable_metrics"`
+
+	// PrettyJSON indents JSON responses for easier reading when debugging.
+	PrettyJSON bool `json:"pretty_json"`
+
+	// WorkerQueueSize bounds how many tasks the worker buffers before
+	// WorkerQueuePolicy applies.
+	WorkerQueueSize int `json:"worker_queue_size"`
+
+	// WorkerQueuePolicy decides what submitting to a full worker queue
+	// does: block until there is room, or drop-oldest to discard the task
+	// that has waited longest.
+	WorkerQueuePolicy string `json:"worker_queue_policy"`
+
+	// TrustProxy takes client IPs from X-Forwarded-For/X-Real-IP set by a
+	// load balancer on a private network.
+	TrustProxy bool `json:"trust_proxy"`
+
+	// ForceHTTPS redirects plain-HTTP requests to HTTPS. Behind a proxy that
+	// terminates TLS it relies on X-Forwarded-Proto, so set TrustProxy too.
+	ForceHTTPS bool `json:"force_https"`
+
+	// SecurityHeaders sets X-Content-Type-Options, X-Frame-Options,
+	// Referrer-Policy and, over HTTPS, Strict-Transport-Security.
+	SecurityHeaders bool `json:"security_headers"`
+
+	// SecurityHeaderOverrides replaces the value of a security header by
+	// name, or adds one; an empty value drops that header.
+	SecurityHeaderOverrides map[string]string `json:"security_header_overrides,omitempty"`
+
+	// AccessLogMode selects which requests are logged: all, errors or none.
+	AccessLogMode string `json:"access_log_mode"`
+
+	// LogLevel is the minimum level logged: debug, info, warn or error.
+	LogLevel string `json:"log_level" reload:"hot"`
 }
 
-// Load creates a new configuration from environment variables.
-func Load() (*Config, error) {
-	cfg := &Config{
+// Default returns the configuration used when nothing overrides it, without
+// reading the environment. Load starts from it before applying overrides.
+func Default() *Config {
+	return &Config{
 		Port:         8080,
 		Host:         "0.0.0.0",
 		Debug:        false,
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
+		IdleTimeout:  60 * time.Second,
+
+		HandlerTimeout:        10 * time.Second,
+		MaxHeaderBytes:        1 << 20,
+		KeepAlives:            true,
+		ReadinessConcurrency:  4,
+		ReadinessTimeout:      5 * time.Second,
+		DiskCheckMinFreeBytes: 100 << 20,
+		EnableMetrics:         true,
+		SecurityHeaders:       true,
+		WorkerQueueSize:       100,
+		WorkerQueuePolicy:     QueuePolicyBlock,
+		AccessLogMode:         AccessLogAll,
+		LogLevel:              LogLevelInfo,
 	}
+}
+
+// Load creates a new configuration from environment variables.
+func Load() (*Config, error) {
+	return LoadWithPrefix("")
+}
+
+// LoadWithPrefix is Load reading every variable with the given prefix, so
+// LoadWithPrefix("MYAPP") reads MYAPP_PORT, MYAPP_DEBUG and so on. An
+// underscore separator is added when the prefix lacks one.
+func LoadWithPrefix(prefix string) (*Config, error) {
+	if prefix != "" && !strings.HasSuffix(prefix, "_") {
+		prefix += "_"
+	}
+
+	cfg := Default()
 
 	// Override with environment variables
-	if port := os.Getenv("PORT"); port != "" {
-		p, err := strconv.Atoi(port)
-		if err != nil {
-			return nil, fmt.Errorf("invalid PORT value: %w", err)
+	var err error
+	if cfg.Port, err = env.Int(prefix+"PORT", cfg.Port); err != nil {
+		return nil, err
+	}
+
+	cfg.Host = env.String(prefix+"HOST", cfg.Host)
+
+	if addrs := parseList(env.String(prefix+"LISTEN_ADDRESSES", "")); addrs != nil {
+		cfg.ListenAddresses = addrs
+	}
+
+	if cfg.AutoPort, err = env.Bool(prefix+"AUTO_PORT", cfg.AutoPort); err != nil {
+		return nil, err
+	}
+
+	cfg.BasePath = env.String(prefix+"BASE_PATH", cfg.BasePath)
+
+	if cfg.Debug, err = env.Bool(prefix+"DEBUG", cfg.Debug); err != nil {
+		return nil, err
+	}
+
+	if cfg.ReadTimeout, err = env.Duration(prefix+"READ_TIMEOUT", cfg.ReadTimeout); err != nil {
+		return nil, err
+	}
+
+	if cfg.WriteTimeout, err = env.Duration(prefix+"WRITE_TIMEOUT", cfg.WriteTimeout); err != nil {
+		return nil, err
+	}
+
+	if cfg.IdleTimeout, err = env.Duration(prefix+"IDLE_TIMEOUT", cfg.IdleTimeout); err != nil {
+		return nil, err
+	}
+
+	if cfg.HandlerTimeout, err = env.Duration(prefix+"HANDLER_TIMEOUT", cfg.HandlerTimeout); err != nil {
+		return nil, err
+	}
+
+	if cfg.MaxHeaderBytes, err = env.Int(prefix+"MAX_HEADER_BYTES", cfg.MaxHeaderBytes); err != nil {
+		return nil, err
+	}
+
+	if cfg.MaxConnections, err = env.Int(prefix+"MAX_CONNECTIONS", cfg.MaxConnections); err != nil {
+		return nil, err
+	}
+
+	if cfg.KeepAlives, err = env.Bool(prefix+"KEEP_ALIVES", cfg.KeepAlives); err != nil {
+		return nil, err
+	}
+
+	if cfg.ReadinessConcurrency, err = env.Int(prefix+"READINESS_CONCURRENCY", cfg.ReadinessConcurrency); err != nil {
+		return nil, err
+	}
+
+	if cfg.ReadinessTimeout, err = env.Duration(prefix+"READINESS_TIMEOUT", cfg.ReadinessTimeout); err != nil {
+		return nil, err
+	}
+
+	if cfg.PreStopDelay, err = env.Duration(prefix+"PRESTOP_DELAY", cfg.PreStopDelay); err != nil {
+		return nil, err
+	}
+
+	cfg.DiskCheckPath = env.String(prefix+"DISK_CHECK_PATH", cfg.DiskCheckPath)
+	minFree, err := env.Int(prefix+"DISK_CHECK_MIN_FREE_BYTES", int(cfg.DiskCheckMinFreeBytes))
+	if err != nil {
+		return nil, err
+	}
+	cfg.DiskCheckMinFreeBytes = int64(minFree)
+
+	if urls := parseList(env.String(prefix+"DEPENDENCY_URLS", "")); urls != nil {
+		cfg.DependencyURLs = urls
+	}
+
+	if origins := parseList(env.String(prefix+"CORS_ALLOWED_ORIGINS", "")); origins != nil {
+		cfg.CORSAllowedOrigins = origins
+	}
+
+	if buckets := env.String(prefix+"METRICS_BUCKETS", ""); buckets != "" {
+		if cfg.MetricsBuckets, err = parseBuckets(buckets); err != nil {
+			return nil, err
 		}
-		cfg.Port = p
 	}
 
-	if host := os.Getenv("HOST"); host != "" {
-		cfg.Host = host
+	if cfg.DisableInfoEndpoint, err = env.Bool(prefix+"DISABLE_INFO_ENDPOINT", cfg.DisableInfoEndpoint); err != nil {
+		return nil, err
 	}
 
-	if debug := os.Getenv("DEBUG"); debug == "true" {
-		cfg.Debug = true
+	if cfg.EnableMetrics, err = env.Bool(prefix+"ENABLE_METRICS", cfg.EnableMetrics); err != nil {
+		return nil, err
 	}
 
-	if timeout := os.Getenv("READ_TIMEOUT"); timeout != "" {
-		t, err := time.ParseDuration(timeout)
-		if err != nil {
-			return nil, fmt.Errorf("invalid READ timeout: %w", err)
+	if cfg.PrettyJSON, err = env.Bool(prefix+"PRETTY_JSON", cfg.PrettyJSON); err != nil {
+		return nil, err
+	}
+
+	if cfg.WorkerQueueSize, err = env.Int(prefix+"WORKER_QUEUE_SIZE", cfg.WorkerQueueSize); err != nil {
+		return nil, err
+	}
+
+	cfg.WorkerQueuePolicy = env.String(prefix+"WORKER_QUEUE_POLICY", cfg.WorkerQueuePolicy)
+
+	if cfg.TrustProxy, err = env.Bool(prefix+"TRUST_PROXY", cfg.TrustProxy); err != nil {
+		return nil, err
+	}
+	if cfg.ForceHTTPS, err = env.Bool(prefix+"FORCE_HTTPS", cfg.ForceHTTPS); err != nil {
+		return nil, err
+	}
+
+	if cfg.SecurityHeaders, err = env.Bool(prefix+"SECURITY_HEADERS", cfg.SecurityHeaders); err != nil {
+		return nil, err
+	}
+	if overrides := env.String(prefix+"SECURITY_HEADER_OVERRIDES", ""); overrides != "" {
+		if cfg.SecurityHeaderOverrides, err = parseHeaderOverrides(overrides); err != nil {
+			return nil, err
 		}
-		cfg.ReadTimeout = t
 	}
 
-	if timeout := os.Getenv("WRITE_TIMEOUT"); timeout != "" {
-		t, err := time.ParseDuration(timeout)
+	cfg.AccessLogMode = env.String(prefix+"ACCESS_LOG_MODE", cfg.AccessLogMode)
+
+	cfg.LogLevel = env.String(prefix+"LOG_LEVEL", cfg.LogLevel)
+
+	databaseURL, err := lookupSecret(prefix + "DATABASE_URL")
+	if err != nil {
+		return nil, err
+	}
+	cfg.DatabaseURL = databaseURL
+
+	adminAPIKey, err := lookupSecret(prefix + "ADMIN_API_KEY")
+	if err != nil {
+		return nil, err
+	}
+	cfg.AdminAPIKey = adminAPIKey
+
+	cfg.TLSCertFile = env.String(prefix+"TLS_CERT_FILE", "")
+	cfg.TLSKeyFile = env.String(prefix+"TLS_KEY_FILE", "")
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		return nil, fmt.Errorf("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Validate checks that the configuration values are usable.
+func (c *Config) Validate() error {
+	if c.IdleTimeout <= 0 {
+		return fmt.Errorf("invalid IDLE_TIMEOUT value: must be positive, got %s", c.IdleTimeout)
+	}
+	if c.HandlerTimeout < 0 {
+		return fmt.Errorf("invalid HANDLER_TIMEOUT value: must not be negative, got %s", c.HandlerTimeout)
+	}
+	if c.PreStopDelay < 0 {
+		return fmt.Errorf("invalid PRESTOP_DELAY value: must not be negative, got %s", c.PreStopDelay)
+	}
+	if c.ReadinessTimeout < 0 {
+		return fmt.Errorf("invalid READINESS_TIMEOUT value: must not be negative, got %s", c.ReadinessTimeout)
+	}
+	if c.MaxHeaderBytes <= 0 {
+		return fmt.Errorf("invalid MAX_HEADER_BYTES value: must be positive, got %d", c.MaxHeaderBytes)
+	}
+	if c.MaxConnections < 0 {
+		return fmt.Errorf("invalid MAX_CONNECTIONS value: must not be negative, got %d", c.MaxConnections)
+	}
+	if c.ReadinessConcurrency < 1 {
+		return fmt.Errorf("invalid READINESS_CONCURRENCY value: must be at least 1, got %d", c.ReadinessConcurrency)
+	}
+	if c.DiskCheckMinFreeBytes < 0 {
+		return fmt.Errorf("invalid DISK_CHECK_MIN_FREE_BYTES value: must not be negative, got %d", c.DiskCheckMinFreeBytes)
+	}
+	if c.WorkerQueueSize < 1 {
+		return fmt.Errorf("invalid WORKER_QUEUE_SIZE value: must be at least 1, got %d", c.WorkerQueueSize)
+	}
+	switch c.WorkerQueuePolicy {
+	case QueuePolicyBlock, QueuePolicyDropOldest:
+	default:
+		return fmt.Errorf("invalid WORKER_QUEUE_POLICY value %q: must be block or drop-oldest", c.WorkerQueuePolicy)
+	}
+	switch c.AccessLogMode {
+	case AccessLogAll, AccessLogErrors, AccessLogNone:
+	default:
+		return fmt.Errorf("invalid ACCESS_LOG_MODE value %q: must be all, errors or none", c.AccessLogMode)
+	}
+	for _, addr := range c.ListenAddresses {
+		if path, ok := strings.CutPrefix(addr, UnixPrefix); ok {
+			if path == "" {
+				return fmt.Errorf("invalid LISTEN_ADDRESSES entry %q: missing socket path", addr)
+			}
+		} else if _, _, err := net.SplitHostPort(addr); err != nil {
+			return fmt.Errorf("invalid LISTEN_ADDRESSES entry %q: %w", addr, err)
+		}
+	}
+	for _, raw := range c.DependencyURLs {
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid DEPENDENCY_URLS entry %q: must be an http or https URL", raw)
+		}
+	}
+	for _, origin := range c.CORSAllowedOrigins {
+		if err := validateOrigin(origin); err != nil {
+			return fmt.Errorf("invalid CORS_ALLOWED_ORIGINS entry %q: %w", origin, err)
+		}
+	}
+	for i, bound := range c.MetricsBuckets {
+		if bound <= 0 || (i > 0 && bound <= c.MetricsBuckets[i-1]) {
+			return fmt.Errorf("invalid METRICS_BUCKETS value: bounds must be positive and increasing, got %v", c.MetricsBuckets)
+		}
+	}
+	switch c.LogLevel {
+	case LogLevelDebug, LogLevelInfo, LogLevelWarn, LogLevelError:
+	default:
+		return fmt.Errorf("invalid LOG_LEVEL value %q: must be debug, info, warn or error", c.LogLevel)
+	}
+	return nil
+}
+
+// parseList splits a comma-separated environment value, trimming
+// whitespace and dropping empty entries, so "a, b,,c " yields [a b c]. It
+// returns nil when no entries remain.
+func parseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseHeaderOverrides parses comma-separated Name=value pairs, so
+// "X-Frame-Options=SAMEORIGIN,Strict-Transport-Security=" changes one header
+// and drops the other.
+func parseHeaderOverrides(value string) (map[string]string, error) {
+	overrides := make(map[string]string)
+	for _, pair := range parseList(value) {
+		name, headerValue, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || !validHeaderName(name) {
+			return nil, fmt.Errorf("invalid SECURITY_HEADER_OVERRIDES entry %q: must be Name=value", pair)
+		}
+		overrides[textproto.CanonicalMIMEHeaderKey(name)] = strings.TrimSpace(headerValue)
+	}
+	return overrides, nil
+}
+
+// validHeaderName reports whether name is a non-empty run of letters,
+// digits and dashes, which covers every header worth overriding.
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// validateOrigin checks that origin is "*" or a bare scheme://host[:port]
+// as browsers send it in the Origin header.
+func validateOrigin(origin string) error {
+	if origin == "*" {
+		return nil
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("must start with http:// or https://")
+	}
+	if u.Host == "" {
+		return fmt.Errorf("missing host")
+	}
+	if (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return fmt.Errorf("must be scheme://host[:port] without a path")
+	}
+	return nil
+}
+
+// parseBuckets parses a comma-separated list of histogram bounds in seconds.
+func parseBuckets(value string) ([]float64, error) {
+	var buckets []float64
+	for _, part := range parseList(value) {
+		bound, err := strconv.ParseFloat(part, 64)
 		if err != nil {
-			return nil, fmt.Errorf("invalid write timeout: %w", err)
+			return nil, fmt.Errorf("invalid METRICS_BUCKETS value: %w", err)
 		}
-		cfg.WriteTimeout = t
+		buckets = append(buckets, bound)
+	}
+	return buckets, nil
+}
+
+// lookupSecret returns the value of the environment variable key or, when it
+// is unset, the contents of the file named by key_FILE (as mounted by Docker
+// and Kubernetes secrets) with trailing newlines trimmed.
+func lookupSecret(key string) (string, error) {
+	if value := os.Getenv(key); value != "" {
+		return value, nil
 	}
 
-	cfg.DatabaseURL = os.Getenv("DATABASE_URL")
+	path := os.Getenv(key + "_FILE")
+	if path == "" {
+		return "", nil
+	}
 
-	return cfg, nil
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s_FILE: %w", key, err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// TLSEnabled reports whether the server should serve HTTPS.
+func (c *Config) TLSEnabled() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+// String renders the configuration like %+v with secret fields (tagged
+// `secret:"true"`) masked, so it is safe to log.
+func (c *Config) String() string {
+	v := reflect.ValueOf(*c)
+	t := v.Type()
+
+	fields := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		value := fmt.Sprint(v.Field(i).Interface())
+		if t.Field(i).Tag.Get("secret") == "true" {
+			value = redact(value)
+		}
+		fields = append(fields, t.Field(i).Name+":"+value)
+	}
+
+	return "{" + strings.Join(fields, " ") + "}"
+}
+
+// Setting is one configuration value as reported by Settings.
+type Setting struct {
+	Key   string
+	Value any
+}
+
+// Settings lists the configuration in field order, keyed by JSON name, with
+// secrets masked as in String and durations rendered like "15s". Fields
+// excluded from JSON (`json:"-"`) are omitted.
+func (c *Config) Settings() []Setting {
+	v := reflect.ValueOf(*c)
+	t := v.Type()
+
+	settings := make([]Setting, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		key, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if key == "-" {
+			continue
+		}
+		if key == "" {
+			key = t.Field(i).Name
+		}
+
+		value := v.Field(i).Interface()
+		switch {
+		case t.Field(i).Tag.Get("secret") == "true":
+			value = redact(fmt.Sprint(value))
+		case v.Field(i).Type() == reflect.TypeOf(time.Duration(0)):
+			value = value.(time.Duration).String()
+		}
+		settings = append(settings, Setting{Key: key, Value: value})
+	}
+	return settings
+}
+
+// redact masks a secret value, keeping a URL scheme so the kind of
+// connection is still visible (postgres://***).
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	if scheme, _, ok := strings.Cut(value, "://"); ok && scheme != "" {
+		return scheme + "://***"
+	}
+	return "***"
 }
 
 // Address returns the full address to bind to.
 func (c *Config) Address() string {
 	return fmt.Sprintf("%s:%d", c.Host, c.Port)
 }
+
+// UnixPrefix marks a ListenAddresses entry as a Unix domain socket path.
+const UnixPrefix = "unix:"
+
+// Listeners returns every address the server should listen on:
+// ListenAddresses when set, otherwise just Address.
+func (c *Config) Listeners() []string {
+	if len(c.ListenAddresses) > 0 {
+		return c.ListenAddresses
+	}
+	return []string{c.Address()}
+}
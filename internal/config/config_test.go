@@ -2,10 +2,24 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
 
+// withEnv sets every variable in vars for the rest of the test and restores
+// the previous values on cleanup, even if the test fails first. Like
+// t.Setenv, it can't be used in parallel tests.
+func withEnv(t *testing.T, vars map[string]string) {
+	t.Helper()
+
+	for key, value := range vars {
+		t.Setenv(key, value)
+	}
+}
+
 func TestLoad(t *testing.T) {
 	// Test default values
 	cfg, err := Load()
@@ -28,23 +42,75 @@ func TestLoad(t *testing.T) {
 	if cfg.ReadTimeout != 15*time.Second {
 		t.Errorf("Expected default read timeout 15s, got %v", cfg.ReadTimeout)
 	}
+
+	if cfg.ReadinessConcurrency != 4 {
+		t.Errorf("Expected default readiness concurrency 4, got %d", cfg.ReadinessConcurrency)
+	}
+
+	if cfg.IdleTimeout != 60*time.Second {
+		t.Errorf("Expected default idle timeout 60s, got %v", cfg.IdleTimeout)
+	}
+
+	if cfg.MaxHeaderBytes != 1<<20 {
+		t.Errorf("Expected default max header bytes %d, got %d", 1<<20, cfg.MaxHeaderBytes)
+	}
 }
 
-func TestLoadWithEnvironment(t *testing.T) {
-	// Set environment variables
-	os.Setenv("PORT", "9000")
-	os.Setenv("HOST", "127.0.0.1")
-	os.Setenv("DEBUG", "true")
-	os.Setenv("READ_TIMEOUT", "30s")
-	os.Setenv("DATABASE_URL", "postgres://localhost/test")
+func TestDefault(t *testing.T) {
+	cfg := Default()
 
-	defer func() {
-		os.Unsetenv("PORT")
-		os.Unsetenv("HOST")
-		os.Unsetenv("DEBUG")
-		os.Unsetenv("READ_TIMEOUT")
-		os.Unsetenv("DATABASE_URL")
-	}()
+	if cfg.Port != 8080 {
+		t.Errorf("Expected default port 8080, got %d", cfg.Port)
+	}
+	if cfg.Host != "0.0.0.0" {
+		t.Errorf("Expected default host '0.0.0.0', got '%s'", cfg.Host)
+	}
+	if cfg.ReadTimeout != 15*time.Second || cfg.WriteTimeout != 15*time.Second {
+		t.Errorf("Expected default read/write timeouts 15s, got %v/%v", cfg.ReadTimeout, cfg.WriteTimeout)
+	}
+	if cfg.IdleTimeout != 60*time.Second {
+		t.Errorf("Expected default idle timeout 60s, got %v", cfg.IdleTimeout)
+	}
+	if cfg.HandlerTimeout != 10*time.Second {
+		t.Errorf("Expected default handler timeout 10s, got %v", cfg.HandlerTimeout)
+	}
+	if cfg.MaxHeaderBytes != 1<<20 {
+		t.Errorf("Expected default max header bytes %d, got %d", 1<<20, cfg.MaxHeaderBytes)
+	}
+	if cfg.AccessLogMode != AccessLogAll {
+		t.Errorf("Expected default access log mode %q, got %q", AccessLogAll, cfg.AccessLogMode)
+	}
+	if cfg.LogLevel != LogLevelInfo {
+		t.Errorf("Expected default log level %q, got %q", LogLevelInfo, cfg.LogLevel)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected defaults to be valid, got %v", err)
+	}
+
+	if Default() == cfg {
+		t.Error("Expected Default() to return a fresh Config each call")
+	}
+}
+
+func TestLoadMatchesDefault(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(cfg, Default()) {
+		t.Errorf("Expected Load() with no env set to equal Default()\ngot:  %+v\nwant: %+v", cfg, Default())
+	}
+}
+
+func TestLoadWithEnvironment(t *testing.T) {
+	withEnv(t, map[string]string{
+		"PORT":         "9000",
+		"HOST":         "127.0.0.1",
+		"DEBUG":        "true",
+		"READ_TIMEOUT": "30s",
+		"DATABASE_URL": "postgres://localhost/test",
+	})
 
 	cfg, err := Load()
 	if err != nil {
@@ -73,8 +139,7 @@ func TestLoadWithEnvironment(t *testing.T) {
 }
 
 func TestLoadInvalidPort(t *testing.T) {
-	os.Setenv("PORT", "invalid")
-	defer os.Unsetenv("PORT")
+	withEnv(t, map[string]string{"PORT": "invalid"})
 
 	_, err := Load()
 	if err == nil {
@@ -82,6 +147,675 @@ func TestLoadInvalidPort(t *testing.T) {
 	}
 }
 
+func TestLoadDebugValues(t *testing.T) {
+	for value, want := range map[string]bool{
+		"true": true, "TRUE": true, "1": true, "yes": true, "Yes": true, "on": true, "ON": true,
+		"false": false, "False": false, "0": false, "no": false, "NO": false, "off": false, "Off": false,
+		" true ": true,
+	} {
+		withEnv(t, map[string]string{"DEBUG": value})
+
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load() with DEBUG=%q returned error: %v", value, err)
+		}
+		if cfg.Debug != want {
+			t.Errorf("Expected debug %t for DEBUG=%q, got %t", want, value, cfg.Debug)
+		}
+	}
+}
+
+func TestLoadInvalidDebug(t *testing.T) {
+	withEnv(t, map[string]string{"DEBUG": "maybe"})
+
+	_, err := Load()
+	if err == nil {
+		t.Fatal("Expected error for DEBUG=maybe")
+	}
+	if !strings.Contains(err.Error(), "DEBUG") {
+		t.Errorf("Expected error to name DEBUG, got %v", err)
+	}
+}
+
+func TestLoadHandlerTimeout(t *testing.T) {
+	defer os.Unsetenv("HANDLER_TIMEOUT")
+
+	os.Setenv("HANDLER_TIMEOUT", "3s")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.HandlerTimeout != 3*time.Second {
+		t.Errorf("Expected handler timeout 3s, got %v", cfg.HandlerTimeout)
+	}
+
+	os.Setenv("HANDLER_TIMEOUT", "-1s")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for negative HANDLER_TIMEOUT")
+	}
+}
+
+func TestLoadMetricsBuckets(t *testing.T) {
+	defer os.Unsetenv("METRICS_BUCKETS")
+
+	os.Setenv("METRICS_BUCKETS", "0.01, 0.1,1")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if len(cfg.MetricsBuckets) != 3 || cfg.MetricsBuckets[0] != 0.01 || cfg.MetricsBuckets[2] != 1 {
+		t.Errorf("Expected buckets [0.01 0.1 1], got %v", cfg.MetricsBuckets)
+	}
+
+	for _, value := range []string{"fast", "1,0.5", "0,1"} {
+		os.Setenv("METRICS_BUCKETS", value)
+		if _, err := Load(); err == nil {
+			t.Errorf("Expected error for METRICS_BUCKETS=%q", value)
+		}
+	}
+}
+
+func TestLoadListenAddresses(t *testing.T) {
+	defer os.Unsetenv("LISTEN_ADDRESSES")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if got := cfg.Listeners(); len(got) != 1 || got[0] != cfg.Address() {
+		t.Errorf("Expected listeners to default to [%s], got %v", cfg.Address(), got)
+	}
+
+	os.Setenv("LISTEN_ADDRESSES", "127.0.0.1:8080, [::1]:8080,unix:/tmp/app.sock")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	want := []string{"127.0.0.1:8080", "[::1]:8080", "unix:/tmp/app.sock"}
+	if !reflect.DeepEqual(cfg.Listeners(), want) {
+		t.Errorf("Expected listeners %v, got %v", want, cfg.Listeners())
+	}
+
+	for _, value := range []string{"localhost", "unix:"} {
+		os.Setenv("LISTEN_ADDRESSES", value)
+		if _, err := Load(); err == nil {
+			t.Errorf("Expected error for LISTEN_ADDRESSES=%q", value)
+		}
+	}
+}
+
+func TestLoadDependencyURLs(t *testing.T) {
+	defer os.Unsetenv("DEPENDENCY_URLS")
+
+	os.Setenv("DEPENDENCY_URLS", "http://billing:8080/health, https://auth.internal/ready")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	want := []string{"http://billing:8080/health", "https://auth.internal/ready"}
+	if !reflect.DeepEqual(cfg.DependencyURLs, want) {
+		t.Errorf("Expected dependency URLs %v, got %v", want, cfg.DependencyURLs)
+	}
+
+	for _, value := range []string{"billing:8080", "ftp://billing/health", "http://"} {
+		os.Setenv("DEPENDENCY_URLS", value)
+		if _, err := Load(); err == nil {
+			t.Errorf("Expected error for DEPENDENCY_URLS=%q", value)
+		}
+	}
+}
+
+func TestLoadAutoPort(t *testing.T) {
+	withEnv(t, map[string]string{"AUTO_PORT": "true"})
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if !cfg.AutoPort {
+		t.Error("Expected AUTO_PORT to enable AutoPort")
+	}
+}
+
+func TestLoadBasePath(t *testing.T) {
+	os.Setenv("BASE_PATH", "/myservice/")
+	defer os.Unsetenv("BASE_PATH")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.BasePath != "/myservice/" {
+		t.Errorf("Expected base path '/myservice/', got '%s'", cfg.BasePath)
+	}
+}
+
+func TestParseList(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"", nil},
+		{" , ,", nil},
+		{"a", []string{"a"}},
+		{" a , b ", []string{"a", "b"}},
+		{"a,,b,", []string{"a", "b"}},
+	}
+
+	for _, tt := range tests {
+		if got := parseList(tt.value); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseList(%q): expected %v, got %v", tt.value, tt.want, got)
+		}
+	}
+}
+
+func TestLoadCORSAllowedOrigins(t *testing.T) {
+	withEnv(t, map[string]string{
+		"CORS_ALLOWED_ORIGINS": " https://app.example.com, ,http://localhost:3000 ",
+	})
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	want := []string{"https://app.example.com", "http://localhost:3000"}
+	if !reflect.DeepEqual(cfg.CORSAllowedOrigins, want) {
+		t.Errorf("Expected origins %v, got %v", want, cfg.CORSAllowedOrigins)
+	}
+}
+
+func TestValidateCORSAllowedOrigins(t *testing.T) {
+	valid := []string{"*", "https://app.example.com", "http://localhost:3000", "https://app.example.com/"}
+	invalid := []string{"app.example.com", "ftp://files.example.com", "https://", "https://app.example.com/login", "https://app.example.com?x=1"}
+
+	for _, origin := range valid {
+		cfg := Default()
+		cfg.CORSAllowedOrigins = []string{origin}
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Expected origin %q to be valid, got %v", origin, err)
+		}
+	}
+	for _, origin := range invalid {
+		cfg := Default()
+		cfg.CORSAllowedOrigins = []string{origin}
+		err := cfg.Validate()
+		if err == nil || !strings.Contains(err.Error(), "CORS_ALLOWED_ORIGINS") {
+			t.Errorf("Expected origin %q to be rejected, got %v", origin, err)
+		}
+	}
+}
+
+func TestLoadTrustProxy(t *testing.T) {
+	os.Setenv("TRUST_PROXY", "true")
+	defer os.Unsetenv("TRUST_PROXY")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if !cfg.TrustProxy {
+		t.Error("Expected TRUST_PROXY to enable TrustProxy")
+	}
+}
+
+func TestLoadForceHTTPS(t *testing.T) {
+	withEnv(t, map[string]string{"FORCE_HTTPS": "on"})
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if !cfg.ForceHTTPS {
+		t.Error("Expected FORCE_HTTPS to enable ForceHTTPS")
+	}
+}
+
+func TestLoadSecurityHeaders(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if !cfg.SecurityHeaders {
+		t.Error("Expected security headers on by default")
+	}
+
+	withEnv(t, map[string]string{
+		"SECURITY_HEADERS":          "false",
+		"SECURITY_HEADER_OVERRIDES": "x-frame-options=SAMEORIGIN, Strict-Transport-Security=",
+	})
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.SecurityHeaders {
+		t.Error("Expected SECURITY_HEADERS=false to disable security headers")
+	}
+
+	want := map[string]string{"X-Frame-Options": "SAMEORIGIN", "Strict-Transport-Security": ""}
+	if !reflect.DeepEqual(cfg.SecurityHeaderOverrides, want) {
+		t.Errorf("Expected overrides %v, got %v", want, cfg.SecurityHeaderOverrides)
+	}
+}
+
+func TestLoadInvalidSecurityHeaderOverrides(t *testing.T) {
+	for _, value := range []string{"X-Frame-Options", "=DENY", "Bad Header=1"} {
+		withEnv(t, map[string]string{"SECURITY_HEADER_OVERRIDES": value})
+
+		_, err := Load()
+		if err == nil || !strings.Contains(err.Error(), "SECURITY_HEADER_OVERRIDES") {
+			t.Errorf("Expected error for SECURITY_HEADER_OVERRIDES=%q, got %v", value, err)
+		}
+	}
+}
+
+func TestLoadDiskCheck(t *testing.T) {
+	withEnv(t, map[string]string{
+		"DISK_CHECK_PATH":           "/var/lib/app",
+		"DISK_CHECK_MIN_FREE_BYTES": "1048576",
+	})
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.DiskCheckPath != "/var/lib/app" {
+		t.Errorf("Expected disk check path /var/lib/app, got %q", cfg.DiskCheckPath)
+	}
+	if cfg.DiskCheckMinFreeBytes != 1<<20 {
+		t.Errorf("Expected disk check minimum %d, got %d", 1<<20, cfg.DiskCheckMinFreeBytes)
+	}
+
+	withEnv(t, map[string]string{"DISK_CHECK_MIN_FREE_BYTES": "-1"})
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "DISK_CHECK_MIN_FREE_BYTES") {
+		t.Errorf("Expected error for negative DISK_CHECK_MIN_FREE_BYTES, got %v", err)
+	}
+}
+
+func TestLoadPreStopDelay(t *testing.T) {
+	withEnv(t, map[string]string{"PRESTOP_DELAY": "5s"})
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.PreStopDelay != 5*time.Second {
+		t.Errorf("Expected prestop delay 5s, got %v", cfg.PreStopDelay)
+	}
+
+	withEnv(t, map[string]string{"PRESTOP_DELAY": "-1s"})
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for negative PRESTOP_DELAY")
+	}
+}
+
+func TestLoadPrettyJSON(t *testing.T) {
+	withEnv(t, map[string]string{"PRETTY_JSON": "true"})
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if !cfg.PrettyJSON {
+		t.Error("Expected PRETTY_JSON to enable PrettyJSON")
+	}
+}
+
+func TestLoadKeepAlives(t *testing.T) {
+	if !Default().KeepAlives {
+		t.Error("Expected keep-alives enabled by default")
+	}
+
+	withEnv(t, map[string]string{"KEEP_ALIVES": "false"})
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.KeepAlives {
+		t.Error("Expected KEEP_ALIVES=false to disable keep-alives")
+	}
+}
+
+func TestLoadEndpointToggles(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if !cfg.EnableMetrics || cfg.DisableInfoEndpoint {
+		t.Errorf("Expected metrics and info endpoints on by default, got %+v", cfg)
+	}
+
+	withEnv(t, map[string]string{"ENABLE_METRICS": "false", "DISABLE_INFO_ENDPOINT": "true"})
+	if cfg, err = Load(); err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.EnableMetrics {
+		t.Error("Expected ENABLE_METRICS=false to disable metrics")
+	}
+	if !cfg.DisableInfoEndpoint {
+		t.Error("Expected DISABLE_INFO_ENDPOINT=true to disable /api/info")
+	}
+}
+
+func TestLoadMaxConnections(t *testing.T) {
+	os.Setenv("MAX_CONNECTIONS", "100")
+	defer os.Unsetenv("MAX_CONNECTIONS")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if cfg.MaxConnections != 100 {
+		t.Errorf("Expected max connections 100, got %d", cfg.MaxConnections)
+	}
+}
+
+func TestLoadInvalidMaxConnections(t *testing.T) {
+	for _, value := range []string{"lots", "-1"} {
+		os.Setenv("MAX_CONNECTIONS", value)
+
+		if _, err := Load(); err == nil {
+			t.Errorf("Expected error for MAX_CONNECTIONS=%q", value)
+		}
+	}
+	os.Unsetenv("MAX_CONNECTIONS")
+}
+
+func TestLoadIdleTimeoutAndMaxHeaderBytes(t *testing.T) {
+	os.Setenv("IDLE_TIMEOUT", "2m")
+	os.Setenv("MAX_HEADER_BYTES", "8192")
+	defer func() {
+		os.Unsetenv("IDLE_TIMEOUT")
+		os.Unsetenv("MAX_HEADER_BYTES")
+	}()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if cfg.IdleTimeout != 2*time.Minute {
+		t.Errorf("Expected idle timeout 2m, got %v", cfg.IdleTimeout)
+	}
+
+	if cfg.MaxHeaderBytes != 8192 {
+		t.Errorf("Expected max header bytes 8192, got %d", cfg.MaxHeaderBytes)
+	}
+}
+
+func TestLoadInvalidIdleTimeoutAndMaxHeaderBytes(t *testing.T) {
+	tests := []struct {
+		key   string
+		value string
+	}{
+		{"IDLE_TIMEOUT", "soon"},
+		{"IDLE_TIMEOUT", "0s"},
+		{"IDLE_TIMEOUT", "-1s"},
+		{"MAX_HEADER_BYTES", "big"},
+		{"MAX_HEADER_BYTES", "0"},
+		{"MAX_HEADER_BYTES", "-1"},
+	}
+
+	for _, tt := range tests {
+		os.Setenv(tt.key, tt.value)
+
+		if _, err := Load(); err == nil {
+			t.Errorf("Expected error for %s=%q", tt.key, tt.value)
+		}
+		os.Unsetenv(tt.key)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cfg := Default()
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() returned error: %v", err)
+	}
+
+	cfg.LogLevel = "verbose"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error for unknown LogLevel")
+	}
+
+	cfg.LogLevel = LogLevelInfo
+	cfg.MaxHeaderBytes = 0
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error for zero MaxHeaderBytes")
+	}
+}
+
+func TestLoadReadinessConcurrency(t *testing.T) {
+	os.Setenv("READINESS_CONCURRENCY", "8")
+	defer os.Unsetenv("READINESS_CONCURRENCY")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if cfg.ReadinessConcurrency != 8 {
+		t.Errorf("Expected readiness concurrency 8, got %d", cfg.ReadinessConcurrency)
+	}
+
+	os.Setenv("READINESS_CONCURRENCY", "0")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for READINESS_CONCURRENCY=0")
+	}
+}
+
+func TestLoadReadinessTimeout(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.ReadinessTimeout != 5*time.Second {
+		t.Errorf("Expected default readiness timeout 5s, got %v", cfg.ReadinessTimeout)
+	}
+
+	withEnv(t, map[string]string{"READINESS_TIMEOUT": "750ms"})
+	if cfg, err = Load(); err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.ReadinessTimeout != 750*time.Millisecond {
+		t.Errorf("Expected readiness timeout 750ms, got %v", cfg.ReadinessTimeout)
+	}
+
+	withEnv(t, map[string]string{"READINESS_TIMEOUT": "-1s"})
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for negative READINESS_TIMEOUT")
+	}
+}
+
+func TestLoadAccessLogMode(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.AccessLogMode != AccessLogAll {
+		t.Errorf("Expected default access log mode %q, got %q", AccessLogAll, cfg.AccessLogMode)
+	}
+
+	os.Setenv("ACCESS_LOG_MODE", "errors")
+	defer os.Unsetenv("ACCESS_LOG_MODE")
+
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.AccessLogMode != AccessLogErrors {
+		t.Errorf("Expected access log mode %q, got %q", AccessLogErrors, cfg.AccessLogMode)
+	}
+
+	os.Setenv("ACCESS_LOG_MODE", "verbose")
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for invalid ACCESS_LOG_MODE")
+	}
+}
+
+func TestLoadTLSFiles(t *testing.T) {
+	os.Setenv("TLS_CERT_FILE", "/etc/tls/tls.crt")
+	os.Setenv("TLS_KEY_FILE", "/etc/tls/tls.key")
+	defer func() {
+		os.Unsetenv("TLS_CERT_FILE")
+		os.Unsetenv("TLS_KEY_FILE")
+	}()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if !cfg.TLSEnabled() {
+		t.Error("Expected TLS to be enabled")
+	}
+}
+
+func TestLoadTLSRequiresBothFiles(t *testing.T) {
+	os.Setenv("TLS_CERT_FILE", "/etc/tls/tls.crt")
+	defer os.Unsetenv("TLS_CERT_FILE")
+
+	_, err := Load()
+	if err == nil {
+		t.Error("Expected error when TLS_KEY_FILE is missing")
+	}
+}
+
+func TestLoadDatabaseURLFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "database_url")
+	if err := os.WriteFile(path, []byte("postgres://from-file/db\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	os.Setenv("DATABASE_URL_FILE", path)
+	defer os.Unsetenv("DATABASE_URL_FILE")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if cfg.DatabaseURL != "postgres://from-file/db" {
+		t.Errorf("Expected database URL from file, got '%s'", cfg.DatabaseURL)
+	}
+
+	// The direct env var takes precedence over the file
+	os.Setenv("DATABASE_URL", "postgres://from-env/db")
+	defer os.Unsetenv("DATABASE_URL")
+
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if cfg.DatabaseURL != "postgres://from-env/db" {
+		t.Errorf("Expected database URL from env, got '%s'", cfg.DatabaseURL)
+	}
+}
+
+func TestLoadDatabaseURLMissingFile(t *testing.T) {
+	os.Setenv("DATABASE_URL_FILE", filepath.Join(t.TempDir(), "missing"))
+	defer os.Unsetenv("DATABASE_URL_FILE")
+
+	if _, err := Load(); err == nil {
+		t.Error("Expected error for missing DATABASE_URL_FILE")
+	}
+}
+
+func TestLoadAdminAPIKey(t *testing.T) {
+	os.Setenv("ADMIN_API_KEY", "s3cret")
+	defer os.Unsetenv("ADMIN_API_KEY")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	if cfg.AdminAPIKey != "s3cret" {
+		t.Errorf("Expected admin API key 's3cret', got '%s'", cfg.AdminAPIKey)
+	}
+}
+
+func TestLoadWithPrefix(t *testing.T) {
+	os.Setenv("MYAPP_PORT", "9100")
+	os.Setenv("MYAPP_DEBUG", "true")
+	os.Setenv("MYAPP_DATABASE_URL", "postgres://prefixed/db")
+	os.Setenv("PORT", "9200")
+	os.Setenv("HOST", "10.0.0.1")
+	defer func() {
+		os.Unsetenv("MYAPP_PORT")
+		os.Unsetenv("MYAPP_DEBUG")
+		os.Unsetenv("MYAPP_DATABASE_URL")
+		os.Unsetenv("PORT")
+		os.Unsetenv("HOST")
+	}()
+
+	for _, prefix := range []string{"MYAPP", "MYAPP_"} {
+		cfg, err := LoadWithPrefix(prefix)
+		if err != nil {
+			t.Fatalf("LoadWithPrefix(%q) returned error: %v", prefix, err)
+		}
+
+		if cfg.Port != 9100 {
+			t.Errorf("Expected prefixed port 9100, got %d", cfg.Port)
+		}
+		if !cfg.Debug {
+			t.Error("Expected prefixed debug to be enabled")
+		}
+		if cfg.DatabaseURL != "postgres://prefixed/db" {
+			t.Errorf("Expected prefixed database URL, got '%s'", cfg.DatabaseURL)
+		}
+		if cfg.Host != "0.0.0.0" {
+			t.Errorf("Expected unprefixed HOST to be ignored, got '%s'", cfg.Host)
+		}
+	}
+}
+
+func TestStringRedactsSecrets(t *testing.T) {
+	cfg := &Config{
+		Port:        8080,
+		Host:        "127.0.0.1",
+		DatabaseURL: "postgres://user:hunter2@db:5432/app",
+		AdminAPIKey: "topsecret",
+	}
+
+	out := cfg.String()
+
+	for _, secret := range []string{"hunter2", "user:", "topsecret"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("Expected %q to be redacted from %s", secret, out)
+		}
+	}
+	for _, visible := range []string{"DatabaseURL:postgres://***", "Port:8080", "Host:127.0.0.1"} {
+		if !strings.Contains(out, visible) {
+			t.Errorf("Expected %q in %s", visible, out)
+		}
+	}
+}
+
+func TestSettingsRedactsSecrets(t *testing.T) {
+	cfg := Default()
+	cfg.DatabaseURL = "postgres://user:hunter2@db:5432/app"
+	cfg.AdminAPIKey = "topsecret"
+
+	settings := make(map[string]any)
+	for _, s := range cfg.Settings() {
+		settings[s.Key] = s.Value
+	}
+
+	if got := settings["database_url"]; got != "postgres://***" {
+		t.Errorf("Expected database_url to be redacted, got %v", got)
+	}
+	if _, ok := settings["admin_api_key"]; ok {
+		t.Error("Expected fields excluded from JSON to be omitted")
+	}
+	if got := settings["read_timeout"]; got != "15s" {
+		t.Errorf("Expected read_timeout rendered as 15s, got %v", got)
+	}
+	if got := settings["port"]; got != 8080 {
+		t.Errorf("Expected port 8080, got %v", got)
+	}
+}
+
 func TestAddress(t *testing.T) {
 	cfg := &Config{
 		Host: "localhost",
@@ -93,3 +827,51 @@ func TestAddress(t *testing.T) {
 		t.Errorf("Expected address '%s', got '%s'", expected, addr)
 	}
 }
+
+func TestWithEnvRestores(t *testing.T) {
+	os.Setenv("PORT", "7000")
+	defer os.Unsetenv("PORT")
+
+	t.Run("override", func(t *testing.T) {
+		withEnv(t, map[string]string{"PORT": "9000", "HOST": "127.0.0.1"})
+
+		if got := os.Getenv("PORT"); got != "9000" {
+			t.Errorf("Expected PORT=9000 inside the test, got %q", got)
+		}
+	})
+
+	if got := os.Getenv("PORT"); got != "7000" {
+		t.Errorf("Expected PORT restored to 7000, got %q", got)
+	}
+	if _, ok := os.LookupEnv("HOST"); ok {
+		t.Error("Expected HOST to be unset again after the test")
+	}
+}
+
+func TestLoadWorkerQueue(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.WorkerQueueSize != 100 || cfg.WorkerQueuePolicy != QueuePolicyBlock {
+		t.Errorf("Expected a blocking queue of 100 by default, got %d %s", cfg.WorkerQueueSize, cfg.WorkerQueuePolicy)
+	}
+
+	withEnv(t, map[string]string{"WORKER_QUEUE_SIZE": "5", "WORKER_QUEUE_POLICY": "drop-oldest"})
+	if cfg, err = Load(); err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if cfg.WorkerQueueSize != 5 || cfg.WorkerQueuePolicy != QueuePolicyDropOldest {
+		t.Errorf("Expected a drop-oldest queue of 5, got %d %s", cfg.WorkerQueueSize, cfg.WorkerQueuePolicy)
+	}
+
+	for _, vars := range []map[string]string{
+		{"WORKER_QUEUE_SIZE": "0", "WORKER_QUEUE_POLICY": "block"},
+		{"WORKER_QUEUE_SIZE": "5", "WORKER_QUEUE_POLICY": "drop-newest"},
+	} {
+		withEnv(t, vars)
+		if _, err := Load(); err == nil {
+			t.Errorf("Expected error for %v", vars)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FileEnv names the environment variable that points services at a config
+// file instead of the environment.
+const FileEnv = "CONFIG_FILE"
+
+// LoadFromFileOrEnv loads configuration the way the services do: from the
+// file named by CONFIG_FILE when it is set, and from the environment
+// otherwise.
+func LoadFromFileOrEnv() (*Config, error) {
+	if path := os.Getenv(FileEnv); path != "" {
+		return LoadFile(path)
+	}
+	return Load()
+}
+
+// LoadFile reads configuration from a JSON file whose keys are the Config
+// json tags, e.g. {"port": 9000, "read_timeout": "30s", "log_level": "debug"}.
+// Durations are written as strings. Unset keys keep their defaults, unknown
+// keys are rejected, and the result is validated.
+//
+// Secrets tagged json:"-" (such as AdminAPIKey) cannot be set from a file.
+func LoadFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	cfg := Default()
+	v := reflect.ValueOf(cfg).Elem()
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		value, ok := raw[name]
+		if name == "" || name == "-" || !ok {
+			continue
+		}
+		delete(raw, name)
+
+		if err := decodeField(v.Field(i), value); err != nil {
+			return nil, fmt.Errorf("invalid %s in config file %s: %w", name, path, err)
+		}
+	}
+
+	if len(raw) > 0 {
+		unknown := make([]string, 0, len(raw))
+		for name := range raw {
+			unknown = append(unknown, name)
+		}
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown keys in config file %s: %s", path, strings.Join(unknown, ", "))
+	}
+
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		return nil, fmt.Errorf("tls_cert_file and tls_key_file must be set together")
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// decodeField unmarshals value into field, parsing durations from strings.
+func decodeField(field reflect.Value, value json.RawMessage) error {
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			return fmt.Errorf("duration must be a string such as \"15s\": %w", err)
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	return json.Unmarshal(value, field.Addr().Interface())
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	content := `{"port": 9000, "read_timeout": "30s", "debug": true, "log_level": "warn"}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() returned error: %v", err)
+	}
+
+	if cfg.Port != 9000 {
+		t.Errorf("Expected port 9000, got %d", cfg.Port)
+	}
+	if cfg.ReadTimeout != 30*time.Second {
+		t.Errorf("Expected read timeout 30s, got %v", cfg.ReadTimeout)
+	}
+	if !cfg.Debug {
+		t.Error("Expected debug to be enabled")
+	}
+	if cfg.LogLevel != LogLevelWarn {
+		t.Errorf("Expected log_level warn, got %s", cfg.LogLevel)
+	}
+	if cfg.WriteTimeout != 15*time.Second {
+		t.Errorf("Expected default write timeout 15s, got %v", cfg.WriteTimeout)
+	}
+}
+
+func TestLoadFromFileOrEnv(t *testing.T) {
+	withEnv(t, map[string]string{"PORT": "9100", FileEnv: ""})
+
+	cfg, err := LoadFromFileOrEnv()
+	if err != nil {
+		t.Fatalf("LoadFromFileOrEnv() returned error: %v", err)
+	}
+	if cfg.Port != 9100 {
+		t.Errorf("Expected the environment's port 9100 without %s, got %d", FileEnv, cfg.Port)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"port": 9200}`), 0o644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	t.Setenv(FileEnv, path)
+
+	cfg, err = LoadFromFileOrEnv()
+	if err != nil {
+		t.Fatalf("LoadFromFileOrEnv() returned error: %v", err)
+	}
+	if cfg.Port != 9200 {
+		t.Errorf("Expected the file's port 9200 with %s set, got %d", FileEnv, cfg.Port)
+	}
+}
+
+func TestLoadFileInvalid(t *testing.T) {
+	tests := map[string]string{
+		"malformed":    `{"port": `,
+		"unknown key":  `{"prot": 9000}`,
+		"bad duration": `{"read_timeout": 30}`,
+		"bad value":    `{"log_level": "verbose"}`,
+
+		// Range checks shared with the environment loader
+		"access log mode":       `{"access_log_mode": "bogus"}`,
+		"max connections":       `{"max_connections": -5}`,
+		"readiness concurrency": `{"readiness_concurrency": 0}`,
+		"worker queue size":     `{"worker_queue_size": 0}`,
+		"worker queue policy":   `{"worker_queue_policy": "drop-newest"}`,
+		"disk check minimum":    `{"disk_check_min_free_bytes": -1}`,
+	}
+
+	dir := t.TempDir()
+	for name, content := range tests {
+		path := filepath.Join(dir, "config.json")
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write config file: %v", err)
+		}
+
+		if _, err := LoadFile(path); err == nil {
+			t.Errorf("Expected error for %s config", name)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+package config
+
+import "log/slog"
+
+// LogEffective logs cfg as a single info-level line so operators can see
+// what a binary actually loaded. Secrets are redacted by String.
+func LogEffective(logger *slog.Logger, app string, cfg *Config) {
+	logger.Info("effective config", "app", app, "config", cfg.String())
+}
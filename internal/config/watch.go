@@ -0,0 +1,105 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// watchInterval is how often Watch checks the file for changes.
+var watchInterval = time.Second
+
+// Watch loads the config file at path and polls it for modifications. On
+// each change the file is reparsed and validated; an invalid edit is logged
+// and ignored, and a valid one is passed to onChange as loaded. Watch keeps
+// no copy of the running config, so the caller applies the `reload:"hot"`
+// fields to its own with ApplyHotReload, the same way it would for any
+// other reload trigger.
+//
+// It returns the initially loaded config and a function that stops watching.
+func Watch(path string, onChange func(*Config)) (*Config, func(), error) {
+	initial, err := LoadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	modTime, size, err := fileVersion(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(watchInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			m, s, err := fileVersion(path)
+			if err != nil || (m.Equal(modTime) && s == size) {
+				continue
+			}
+			modTime, size = m, s
+
+			next, err := LoadFile(path)
+			if err != nil {
+				log.Printf("⚠️  Ignoring config change: %v", err)
+				continue
+			}
+			onChange(next)
+		}
+	}()
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() { close(done) })
+	}
+
+	return initial, stop, nil
+}
+
+// ApplyHotReload returns a copy of current with the hot-reloadable fields of
+// next applied, and whether any of them changed. Each applied field is
+// logged; other differing fields are logged as requiring a restart and keep
+// their current values.
+func ApplyHotReload(current, next *Config) (*Config, bool) {
+	applied := *current
+	av := reflect.ValueOf(&applied).Elem()
+	nv := reflect.ValueOf(next).Elem()
+	t := av.Type()
+
+	changed := false
+	for i := 0; i < t.NumField(); i++ {
+		if reflect.DeepEqual(av.Field(i).Interface(), nv.Field(i).Interface()) {
+			continue
+		}
+
+		field := t.Field(i)
+		if field.Tag.Get("reload") != "hot" {
+			log.Printf("ℹ️  Config field %s changed; requires restart", field.Name)
+			continue
+		}
+
+		log.Printf("🔄 Config field %s changed; applied", field.Name)
+		av.Field(i).Set(nv.Field(i))
+		changed = true
+	}
+
+	return &applied, changed
+}
+
+func fileVersion(path string) (time.Time, int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("failed to stat config file: %w", err)
+	}
+	return info.ModTime(), info.Size(), nil
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, path, content string, modTime time.Time) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	// Set the mtime explicitly so the change is seen on coarse-grained filesystems
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set config file mtime: %v", err)
+	}
+}
+
+func TestWatchReportsValidChanges(t *testing.T) {
+	original := watchInterval
+	watchInterval = 10 * time.Millisecond
+	defer func() { watchInterval = original }()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	start := time.Now().Add(-time.Minute)
+	writeConfigFile(t, path, `{"port": 9000, "log_level": "info"}`, start)
+
+	changes := make(chan *Config, 4)
+	cfg, stop, err := Watch(path, func(cfg *Config) { changes <- cfg })
+	if err != nil {
+		t.Fatalf("Watch() returned error: %v", err)
+	}
+	defer stop()
+
+	if cfg.LogLevel != LogLevelInfo || cfg.Port != 9000 {
+		t.Fatalf("Expected initial log_level info and port 9000, got %s and %d", cfg.LogLevel, cfg.Port)
+	}
+
+	// A bad edit must not take effect
+	writeConfigFile(t, path, `{"port": 9000, "log_level": "verbose"}`, start.Add(time.Second))
+	select {
+	case got := <-changes:
+		t.Fatalf("Expected invalid config to be ignored, got callback with %+v", got)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// The new file is reported as loaded; the caller decides what applies
+	writeConfigFile(t, path, `{"port": 9100, "log_level": "debug"}`, start.Add(2*time.Second))
+	select {
+	case got := <-changes:
+		if got.LogLevel != LogLevelDebug || got.Port != 9100 {
+			t.Errorf("Expected log_level debug and port 9100, got %s and %d", got.LogLevel, got.Port)
+		}
+		applied, changed := ApplyHotReload(cfg, got)
+		if !changed || applied.LogLevel != LogLevelDebug || applied.Port != 9000 {
+			t.Errorf("Expected only the log level to apply, got changed=%t %+v", changed, applied)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected onChange to be called after the file changed")
+	}
+}
+
+func TestWatchMissingFile(t *testing.T) {
+	_, _, err := Watch(filepath.Join(t.TempDir(), "missing.json"), func(*Config) {})
+	if err == nil {
+		t.Error("Expected error for missing config file")
+	}
+}
@@ -0,0 +1,209 @@
+// Package cron parses five-field cron expressions and computes when they
+// are due.
+//
+// Fields are minute (0-59), hour (0-23), day of month (1-31), month (1-12)
+// and day of week (0-6, Sunday is 0 or 7). Each field accepts *, a value, a
+// range (1-5), a step (*/15 or 1-30/5) and comma-separated lists of these.
+// The macros @hourly, @daily (or @midnight), @weekly, @monthly and @yearly
+// (or @annually) are also recognised.
+//
+// As in classic cron, when both day of month and day of week are
+// restricted a time matches if either does.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearch bounds how far ahead Next looks before giving up on a schedule
+// that can never match (such as 30 February).
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	expr string
+
+	minute, hour, dom, month, dow uint64 // bit n set means value n matches
+
+	// domAny and dowAny record a * day field, which changes how the two
+	// day fields combine.
+	domAny, dowAny bool
+}
+
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = [5]field{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// Parse parses a cron expression.
+func Parse(expr string) (*Schedule, error) {
+	spec := strings.TrimSpace(expr)
+	if macro, ok := macros[spec]; ok {
+		spec = macro
+	}
+
+	parts := strings.Fields(spec)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expr, len(parts))
+	}
+
+	var bits [5]uint64
+	for i, part := range parts {
+		b, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+		bits[i] = b
+	}
+
+	// Sunday may be written as 7
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = bits[4]&^(1<<7) | 1
+	}
+
+	return &Schedule{
+		expr:   expr,
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    bits[4],
+		domAny: parts[2] == "*",
+		dowAny: parts[4] == "*",
+	}, nil
+}
+
+// String returns the expression the schedule was parsed from.
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// Matches reports whether t falls in a minute the schedule is due.
+func (s *Schedule) Matches(t time.Time) bool {
+	return has(s.minute, t.Minute()) &&
+		has(s.hour, t.Hour()) &&
+		has(s.month, int(t.Month())) &&
+		s.dayMatches(t)
+}
+
+// Next returns the first minute after t at which the schedule is due, in t's
+// location, or the zero time if there is none within five years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxSearch)
+
+	for t.Before(limit) {
+		switch {
+		case !has(s.month, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !has(s.hour, t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !has(s.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := has(s.dom, t.Day())
+	dow := has(s.dow, int(t.Weekday()))
+
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+func has(bits uint64, n int) bool {
+	return bits&(1<<uint(n)) != 0
+}
+
+// parseField parses one comma-separated field into a bit set.
+func parseField(spec string, f field) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(spec, ",") {
+		b, err := parseItem(item, f)
+		if err != nil {
+			return 0, err
+		}
+		bits |= b
+	}
+	return bits, nil
+}
+
+// parseItem parses *, N, N-M, */S or N-M/S.
+func parseItem(item string, f field) (uint64, error) {
+	rangeSpec, stepSpec, hasStep := strings.Cut(item, "/")
+
+	step := 1
+	if hasStep {
+		n, err := strconv.Atoi(stepSpec)
+		if err != nil || n < 1 {
+			return 0, fmt.Errorf("invalid step %q in %s field", stepSpec, f.name)
+		}
+		step = n
+	}
+
+	lo, hi := f.min, f.max
+	if rangeSpec != "*" {
+		loSpec, hiSpec, isRange := strings.Cut(rangeSpec, "-")
+
+		var err error
+		if lo, err = parseValue(loSpec, f); err != nil {
+			return 0, err
+		}
+		hi = lo
+		if isRange {
+			if hi, err = parseValue(hiSpec, f); err != nil {
+				return 0, err
+			}
+		} else if hasStep {
+			hi = f.max
+		}
+		if lo > hi {
+			return 0, fmt.Errorf("invalid range %q in %s field", rangeSpec, f.name)
+		}
+	}
+
+	var bits uint64
+	for n := lo; n <= hi; n += step {
+		bits |= 1 << uint(n)
+	}
+	return bits, nil
+}
+
+func parseValue(spec string, f field) (int, error) {
+	n, err := strconv.Atoi(spec)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("invalid value %q in %s field: must be %d-%d", spec, f.name, f.min, f.max)
+	}
+	return n, nil
+}
@@ -0,0 +1,83 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+	} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Expected error for %q", expr)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	// Wednesday 2024-01-10 10:07:30 UTC
+	from := time.Date(2024, 1, 10, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, 1, 10, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 1, 10, 10, 15, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2024, 1, 11, 3, 0, 0, 0, time.UTC)},
+		{"30 9 * * 1-5", time.Date(2024, 1, 11, 9, 30, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * * 7", time.Date(2024, 1, 14, 12, 0, 0, 0, time.UTC)},
+		{"0 0 15 * 5", time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)}, // Friday or the 15th
+		{"5,10 10 * * *", time.Date(2024, 1, 10, 10, 10, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, 1, 10, 11, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		s, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", tt.expr, err)
+		}
+
+		if got := s.Next(from); !got.Equal(tt.want) {
+			t.Errorf("Next(%q): expected %v, got %v", tt.expr, tt.want, got)
+		}
+	}
+}
+
+func TestNextImpossible(t *testing.T) {
+	s, err := Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+
+	if got := s.Next(time.Now()); !got.IsZero() {
+		t.Errorf("Expected zero time for 30 February, got %v", got)
+	}
+}
+
+func TestMatches(t *testing.T) {
+	s, err := Parse("30 9 * * 1-5")
+	if err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+
+	if !s.Matches(time.Date(2024, 1, 10, 9, 30, 45, 0, time.UTC)) {
+		t.Error("Expected Wednesday 09:30 to match")
+	}
+	if s.Matches(time.Date(2024, 1, 13, 9, 30, 0, 0, time.UTC)) {
+		t.Error("Expected Saturday 09:30 not to match")
+	}
+}
@@ -0,0 +1,67 @@
+// Package env reads typed values from environment variables.
+//
+// Each helper returns the default when the variable is unset or empty, and
+// an error naming the variable when its value cannot be parsed.
+package env
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// String returns the value of key, or def when it is unset or empty.
+func String(key, def string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return def
+}
+
+// Int returns the value of key parsed as a base-10 integer.
+func Int(key string, def int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return def, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return def, fmt.Errorf("invalid %s value: %w", key, err)
+	}
+	return n, nil
+}
+
+// Bool returns the value of key parsed as a boolean. It accepts true/false,
+// 1/0, yes/no and on/off, case-insensitively.
+func Bool(key string, def bool) (bool, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return def, nil
+	}
+
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "1", "yes", "on":
+		return true, nil
+	case "false", "0", "no", "off":
+		return false, nil
+	default:
+		return def, fmt.Errorf("invalid %s value %q: must be true/false, 1/0, yes/no or on/off", key, value)
+	}
+}
+
+// Duration returns the value of key parsed with time.ParseDuration.
+func Duration(key string, def time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return def, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return def, fmt.Errorf("invalid %s value: %w", key, err)
+	}
+	return d, nil
+}
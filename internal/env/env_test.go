@@ -0,0 +1,85 @@
+package env
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestString(t *testing.T) {
+	t.Setenv("ENV_TEST_STRING", "")
+	if got := String("ENV_TEST_STRING", "fallback"); got != "fallback" {
+		t.Errorf("Expected default 'fallback', got '%s'", got)
+	}
+
+	t.Setenv("ENV_TEST_STRING", "value")
+	if got := String("ENV_TEST_STRING", "fallback"); got != "value" {
+		t.Errorf("Expected 'value', got '%s'", got)
+	}
+}
+
+func TestInt(t *testing.T) {
+	t.Setenv("ENV_TEST_INT", "")
+	n, err := Int("ENV_TEST_INT", 42)
+	if err != nil || n != 42 {
+		t.Errorf("Expected default 42, got %d (err %v)", n, err)
+	}
+
+	t.Setenv("ENV_TEST_INT", "7")
+	n, err = Int("ENV_TEST_INT", 42)
+	if err != nil || n != 7 {
+		t.Errorf("Expected 7, got %d (err %v)", n, err)
+	}
+
+	t.Setenv("ENV_TEST_INT", "seven")
+	if _, err := Int("ENV_TEST_INT", 42); err == nil || !strings.Contains(err.Error(), "ENV_TEST_INT") {
+		t.Errorf("Expected parse error naming ENV_TEST_INT, got %v", err)
+	}
+}
+
+func TestBool(t *testing.T) {
+	t.Setenv("ENV_TEST_BOOL", "")
+	b, err := Bool("ENV_TEST_BOOL", true)
+	if err != nil || !b {
+		t.Errorf("Expected default true, got %t (err %v)", b, err)
+	}
+
+	tests := map[string]bool{
+		"true": true, "TRUE": true, "1": true, "yes": true, "on": true,
+		"false": false, "False": false, "0": false, "no": false, "off": false,
+	}
+	for value, want := range tests {
+		t.Setenv("ENV_TEST_BOOL", value)
+		got, err := Bool("ENV_TEST_BOOL", !want)
+		if err != nil {
+			t.Errorf("Bool(%q) returned error: %v", value, err)
+		}
+		if got != want {
+			t.Errorf("Bool(%q): expected %t, got %t", value, want, got)
+		}
+	}
+
+	t.Setenv("ENV_TEST_BOOL", "maybe")
+	if _, err := Bool("ENV_TEST_BOOL", false); err == nil || !strings.Contains(err.Error(), "ENV_TEST_BOOL") {
+		t.Errorf("Expected parse error naming ENV_TEST_BOOL, got %v", err)
+	}
+}
+
+func TestDuration(t *testing.T) {
+	t.Setenv("ENV_TEST_DURATION", "")
+	d, err := Duration("ENV_TEST_DURATION", time.Second)
+	if err != nil || d != time.Second {
+		t.Errorf("Expected default 1s, got %v (err %v)", d, err)
+	}
+
+	t.Setenv("ENV_TEST_DURATION", "250ms")
+	d, err = Duration("ENV_TEST_DURATION", time.Second)
+	if err != nil || d != 250*time.Millisecond {
+		t.Errorf("Expected 250ms, got %v (err %v)", d, err)
+	}
+
+	t.Setenv("ENV_TEST_DURATION", "soon")
+	if _, err := Duration("ENV_TEST_DURATION", time.Second); err == nil || !strings.Contains(err.Error(), "ENV_TEST_DURATION") {
+		t.Errorf("Expected parse error naming ENV_TEST_DURATION, got %v", err)
+	}
+}
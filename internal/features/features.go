@@ -0,0 +1,119 @@
+// Package features reads runtime feature toggles from the environment.
+//
+// A flag named new_ui is set with FEATURE_NEW_UI=true; the value accepts
+// the same forms as env.Bool. Flags are read once, on first use or by
+// Refresh at startup, and only change when Refresh is called again. A
+// FEATURE_* variable whose value isn't a boolean, such as a
+// FEATURE_FLAGS_URL that merely shares the prefix, is logged and skipped.
+package features
+
+import (
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/your-org/go-template-project/internal/env"
+)
+
+// Prefix is prepended to a flag's upper-cased name to form its env var.
+const Prefix = "FEATURE_"
+
+// Flags is a snapshot of the feature flags set in the environment.
+type Flags struct {
+	mu      sync.RWMutex
+	enabled map[string]bool
+}
+
+// Load reads every FEATURE_* variable into a new Flags.
+func Load() *Flags {
+	f := &Flags{}
+	f.Refresh()
+	return f
+}
+
+// Refresh re-reads the FEATURE_* variables, skipping any that don't hold a
+// boolean.
+func (f *Flags) Refresh() {
+	enabled := make(map[string]bool)
+	for _, kv := range os.Environ() {
+		key, _, _ := strings.Cut(kv, "=")
+		name, ok := strings.CutPrefix(key, Prefix)
+		if !ok || name == "" {
+			continue
+		}
+
+		on, err := env.Bool(key, false)
+		if err != nil {
+			log.Printf("⚠️  Ignoring feature flag: %v", err)
+			continue
+		}
+		enabled[strings.ToLower(name)] = on
+	}
+
+	f.mu.Lock()
+	f.enabled = enabled
+	f.mu.Unlock()
+}
+
+// Enabled reports whether the flag is on. Names are case-insensitive and
+// dashes match underscores, so "new-ui" reads FEATURE_NEW_UI; an unset flag
+// is off.
+func (f *Flags) Enabled(name string) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.enabled[normalize(name)]
+}
+
+// List returns the names of the flags that are on, sorted.
+func (f *Flags) List() []string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	var names []string
+	for name, on := range f.enabled {
+		if on {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// EnvVar returns the environment variable that sets the named flag.
+func EnvVar(name string) string {
+	return Prefix + strings.ToUpper(normalize(name))
+}
+
+func normalize(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "-", "_"))
+}
+
+var (
+	std     Flags
+	stdOnce sync.Once
+)
+
+// loadStd reads the process-wide flags the first time they are needed.
+func loadStd() *Flags {
+	stdOnce.Do(std.Refresh)
+	return &std
+}
+
+// Enabled reports whether the flag is on in the process-wide flags.
+func Enabled(name string) bool {
+	return loadStd().Enabled(name)
+}
+
+// List returns the process-wide flags that are on.
+func List() []string {
+	return loadStd().List()
+}
+
+// Refresh re-reads the process-wide flags. Call it at startup so skipped
+// FEATURE_* values are logged before the service starts.
+func Refresh() {
+	stdOnce.Do(func() {})
+	std.Refresh()
+}
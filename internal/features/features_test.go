@@ -0,0 +1,99 @@
+package features
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFlagsEnabled(t *testing.T) {
+	t.Setenv("FEATURE_NEW_UI", "true")
+	t.Setenv("FEATURE_BETA_SEARCH", "off")
+
+	f := Load()
+
+	tests := map[string]bool{
+		"new_ui":      true,
+		"NEW_UI":      true,
+		"new-ui":      true,
+		"beta_search": false,
+		"unset_flag":  false,
+	}
+	for name, want := range tests {
+		if got := f.Enabled(name); got != want {
+			t.Errorf("Enabled(%q) = %t, want %t", name, got, want)
+		}
+	}
+
+	if got := f.List(); !slices.Contains(got, "new_ui") || slices.Contains(got, "beta_search") {
+		t.Errorf("Expected List() to contain only enabled flags, got %v", got)
+	}
+}
+
+func TestFlagsRefresh(t *testing.T) {
+	t.Setenv("FEATURE_DARK_MODE", "")
+
+	f := Load()
+	if f.Enabled("dark_mode") {
+		t.Error("Expected dark_mode off before it is set")
+	}
+
+	t.Setenv("FEATURE_DARK_MODE", "1")
+	if f.Enabled("dark_mode") {
+		t.Error("Expected flags to stay unchanged until Refresh")
+	}
+
+	f.Refresh()
+	if !f.Enabled("dark_mode") {
+		t.Error("Expected dark_mode on after Refresh")
+	}
+}
+
+func TestFlagsSkipMalformedValues(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	t.Setenv("FEATURE_NEW_UI", "true")
+	t.Setenv("FEATURE_FLAGS_URL", "https://flags.example.com")
+
+	f := Load()
+
+	if !f.Enabled("new_ui") {
+		t.Error("Expected a malformed flag not to affect the others")
+	}
+	if f.Enabled("flags_url") || slices.Contains(f.List(), "flags_url") {
+		t.Errorf("Expected FEATURE_FLAGS_URL to be skipped, got %v", f.List())
+	}
+	if !strings.Contains(buf.String(), "FEATURE_FLAGS_URL") {
+		t.Errorf("Expected the skipped variable to be logged, got %q", buf.String())
+	}
+}
+
+func TestEnvVar(t *testing.T) {
+	tests := map[string]string{
+		"new_ui": "FEATURE_NEW_UI",
+		"new-ui": "FEATURE_NEW_UI",
+		"NewUI":  "FEATURE_NEWUI",
+	}
+	for name, want := range tests {
+		if got := EnvVar(name); got != want {
+			t.Errorf("EnvVar(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestProcessWideFlags(t *testing.T) {
+	t.Setenv("FEATURE_GLOBAL_TOGGLE", "yes")
+	Refresh()
+
+	if !Enabled("global_toggle") {
+		t.Error("Expected global_toggle on")
+	}
+	if !slices.Contains(List(), "global_toggle") {
+		t.Errorf("Expected List() to contain global_toggle, got %v", List())
+	}
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+)
+
+type clientIPKey struct{}
+
+// ClientIPMiddleware resolves each request's client IP once and stores it
+// for ClientIP. With trustProxy set, the IP comes from X-Forwarded-For or
+// X-Real-IP when the connection is from a trusted proxy; otherwise those
+// headers are ignored, since any client can send them.
+func ClientIPMiddleware(trustProxy bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := remoteIP(r)
+			if trustProxy {
+				ip = forwardedIP(r, ip)
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientIPKey{}, ip)))
+		})
+	}
+}
+
+// ClientIP returns the client IP resolved by ClientIPMiddleware, or the
+// connection's remote address when the middleware is not installed.
+func ClientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(clientIPKey{}).(string); ok {
+		return ip
+	}
+	return remoteIP(r)
+}
+
+// remoteIP returns the host part of r.RemoteAddr.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// forwardedIP walks X-Forwarded-For from the nearest hop outwards and
+// returns the first address that is not a trusted proxy, falling back to
+// X-Real-IP. Only a connection from a trusted proxy may forward headers.
+func forwardedIP(r *http.Request, remote string) string {
+	if !trustedProxy(remote) {
+		return remote
+	}
+
+	var hops []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		for _, hop := range strings.Split(header, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		if net.ParseIP(hops[i]) == nil {
+			// A malformed hop can't be trusted to describe anything further out
+			break
+		}
+		if !trustedProxy(hops[i]) {
+			return hops[i]
+		}
+	}
+
+	if realIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); net.ParseIP(realIP) != nil {
+		return realIP
+	}
+
+	return remote
+}
+
+// trustedProxy reports whether ip is a loopback, private or link-local
+// address, where load balancers and ingress proxies live.
+func trustedProxy(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	return parsed.IsLoopback() || parsed.IsPrivate() || parsed.IsLinkLocalUnicast()
+}
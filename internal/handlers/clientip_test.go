@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		trustProxy bool
+		remoteAddr string
+		headers    map[string]string
+		want       string
+	}{
+		{
+			name:       "direct connection",
+			remoteAddr: "203.0.113.7:51234",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "trusted proxy with X-Forwarded-For",
+			trustProxy: true,
+			remoteAddr: "10.0.0.5:443",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.9, 10.0.0.4"},
+			want:       "198.51.100.9",
+		},
+		{
+			name:       "trusted proxy takes first untrusted hop",
+			trustProxy: true,
+			remoteAddr: "10.0.0.5:443",
+			headers:    map[string]string{"X-Forwarded-For": "1.2.3.4, 198.51.100.9, 10.0.0.4"},
+			want:       "198.51.100.9",
+		},
+		{
+			name:       "trusted proxy with X-Real-IP",
+			trustProxy: true,
+			remoteAddr: "127.0.0.1:443",
+			headers:    map[string]string{"X-Real-IP": "198.51.100.9"},
+			want:       "198.51.100.9",
+		},
+		{
+			name:       "spoofed headers ignored when trust is off",
+			remoteAddr: "203.0.113.7:51234",
+			headers:    map[string]string{"X-Forwarded-For": "1.2.3.4", "X-Real-IP": "5.6.7.8"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "spoofed headers ignored from untrusted peer",
+			trustProxy: true,
+			remoteAddr: "203.0.113.7:51234",
+			headers:    map[string]string{"X-Forwarded-For": "1.2.3.4"},
+			want:       "203.0.113.7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			var got string
+			handler := ClientIPMiddleware(tt.trustProxy)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = ClientIP(r)
+			}))
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Errorf("Expected client IP %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestClientIPWithoutMiddleware(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "203.0.113.7:51234"
+	req.Header.Set("X-Forwarded-For", "1.2.3.4")
+
+	if got := ClientIP(req); got != "203.0.113.7" {
+		t.Errorf("Expected remote address 203.0.113.7, got %s", got)
+	}
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+)
+
+// corsAllowedMethods is the Access-Control-Allow-Methods value sent on
+// preflight responses.
+const corsAllowedMethods = "GET, HEAD, POST, PUT, PATCH, DELETE, OPTIONS"
+
+// CORSMiddleware allows cross-origin requests from the given origins
+// (scheme://host[:port], or "*" for any). Requests from an allowed origin
+// get Access-Control-Allow-Origin; preflight OPTIONS requests from one are
+// answered with 204 without reaching the handler. Requests from other
+// origins pass through without CORS headers, so the browser blocks them.
+func CORSMiddleware(origins []string) func(http.Handler) http.Handler {
+	allowAny := false
+	allowed := make(map[string]bool, len(origins))
+	for _, origin := range origins {
+		if origin == "*" {
+			allowAny = true
+			continue
+		}
+		allowed[normalizeOrigin(origin)] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Add("Vary", "Origin")
+			if !allowAny && !allowed[normalizeOrigin(origin)] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if allowAny {
+				h.Set("Access-Control-Allow-Origin", "*")
+			} else {
+				h.Set("Access-Control-Allow-Origin", origin)
+			}
+
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				h.Set("Access-Control-Allow-Methods", corsAllowedMethods)
+				if headers := r.Header.Get("Access-Control-Request-Headers"); headers != "" {
+					h.Set("Access-Control-Allow-Headers", headers)
+				}
+				h.Set("Access-Control-Max-Age", "600")
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// normalizeOrigin lowercases an origin and drops a trailing slash so
+// "https://App.example.com/" matches the Origin header browsers send.
+func normalizeOrigin(origin string) string {
+	return strings.ToLower(strings.TrimSuffix(origin, "/"))
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/your-org/go-template-project/internal/httpclient"
+)
+
+// dependencyTimeout bounds each HTTP dependency check so a hung upstream
+// can't stall the readiness probe.
+var dependencyTimeout = 2 * time.Second
+
+// dependencyClient is shared by HTTP dependency checks. It retries
+// transient failures within the check's timeout.
+var dependencyClient = newDependencyClient()
+
+func newDependencyClient() *httpclient.Client {
+	c := httpclient.New(0)
+	// Report the upstream's own status rather than following redirects
+	c.HTTP.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return c
+}
+
+// HTTPDependencyCheck returns a readiness check that GETs url and passes
+// when the upstream answers with a 2xx status within the timeout. Transport
+// errors and 5xx responses are retried before the check fails. Register
+// it under name:
+//
+//	registry.Register("billing", HTTPDependencyCheck("billing", url))
+func HTTPDependencyCheck(name, url string) ReadinessFunc {
+	return func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, dependencyTimeout)
+		defer cancel()
+
+		resp, err := dependencyClient.Get(ctx, url)
+		if err != nil {
+			return fmt.Errorf("%s unreachable: %w", name, err)
+		}
+		defer resp.Body.Close()
+
+		// Drain a little so the connection can be reused
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4<<10))
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("%s returned status %d", name, resp.StatusCode)
+		}
+		return nil
+	}
+}
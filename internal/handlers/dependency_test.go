@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTTPDependencyCheckHealthy(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer upstream.Close()
+
+	registry := NewReadinessRegistry(1)
+	registry.Register("billing", HTTPDependencyCheck("billing", upstream.URL))
+
+	results := registry.Run(context.Background())
+	if len(results) != 1 || results[0].Status != CheckStatusOK {
+		t.Errorf("Expected billing to be ready, got %+v", results)
+	}
+}
+
+func TestHTTPDependencyCheckServerError(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer upstream.Close()
+
+	err := HTTPDependencyCheck("billing", upstream.URL)(context.Background())
+	if err == nil {
+		t.Fatal("Expected error for upstream returning 500")
+	}
+	if !strings.Contains(err.Error(), "billing returned status 500") {
+		t.Errorf("Expected error to name the dependency and status, got %v", err)
+	}
+}
+
+func TestHTTPDependencyCheckTimeout(t *testing.T) {
+	original := dependencyTimeout
+	dependencyTimeout = 50 * time.Millisecond
+	defer func() { dependencyTimeout = original }()
+
+	release := make(chan struct{})
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer upstream.Close()
+	defer close(release)
+
+	start := time.Now()
+	err := HTTPDependencyCheck("billing", upstream.URL)(context.Background())
+	if err == nil {
+		t.Fatal("Expected error for upstream that never answers")
+	}
+	if !strings.Contains(err.Error(), "billing unreachable") {
+		t.Errorf("Expected unreachable error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected check to give up after the timeout, took %v", elapsed)
+	}
+}
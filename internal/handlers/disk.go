@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// DiskCheck returns a readiness check that fails when the filesystem
+// holding path has less than minFreeBytes available or a file can't be
+// written in path, as on a full or read-only volume. Register it for
+// services that write to disk:
+//
+//	registry.Register("disk", DiskCheck("/var/lib/app", 100<<20))
+func DiskCheck(path string, minFreeBytes int64) ReadinessFunc {
+	return func(ctx context.Context) error {
+		free, err := diskFreeBytes(path)
+		if err != nil {
+			return fmt.Errorf("failed to stat filesystem for %s: %w", path, err)
+		}
+		if free < uint64(max(minFreeBytes, 0)) {
+			return fmt.Errorf("%s has %d bytes free, below the %d byte minimum", path, free, minFreeBytes)
+		}
+
+		return probeWritable(path)
+	}
+}
+
+// probeWritable creates and removes a small file in dir.
+func probeWritable(dir string) error {
+	f, err := os.CreateTemp(dir, ".readiness-*")
+	if err != nil {
+		return fmt.Errorf("%s is not writable: %w", dir, err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write([]byte{0}); err != nil {
+		f.Close()
+		return fmt.Errorf("%s is not writable: %w", dir, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%s is not writable: %w", dir, err)
+	}
+	return nil
+}
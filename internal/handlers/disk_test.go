@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiskCheckWritable(t *testing.T) {
+	dir := t.TempDir()
+
+	registry := NewReadinessRegistry(1)
+	registry.Register("disk", DiskCheck(dir, 1))
+
+	results := registry.Run(context.Background())
+	if len(results) != 1 || results[0].Status != CheckStatusOK {
+		t.Errorf("Expected disk to be ready, got %+v", results)
+	}
+
+	// The probe file is cleaned up
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected the probe file to be removed, found %d entries", len(entries))
+	}
+}
+
+func TestDiskCheckBelowThreshold(t *testing.T) {
+	dir := t.TempDir()
+
+	registry := NewReadinessRegistry(1)
+	registry.Register("disk", DiskCheck(dir, math.MaxInt64))
+
+	results := registry.Run(context.Background())
+	if len(results) != 1 || results[0].Status != CheckStatusFailed {
+		t.Fatalf("Expected disk to be not ready, got %+v", results)
+	}
+	if !strings.Contains(results[0].Error, "below the") {
+		t.Errorf("Expected error to report the free-space minimum, got %q", results[0].Error)
+	}
+}
+
+func TestDiskCheckNotWritable(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("root can write to read-only directories")
+	}
+
+	dir := t.TempDir()
+	if err := os.Chmod(dir, 0o555); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chmod(dir, 0o755) })
+
+	err := DiskCheck(dir, 0)(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "not writable") {
+		t.Errorf("Expected a not writable error, got %v", err)
+	}
+}
+
+func TestDiskCheckMissingPath(t *testing.T) {
+	err := DiskCheck(filepath.Join(t.TempDir(), "missing"), 0)(context.Background())
+	if err == nil {
+		t.Error("Expected error for a missing path")
+	}
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import "net/http"
+
+// ErrorResponse is the JSON envelope for every error response:
+//
+//	{"error": {"code": 405, "message": "Method not allowed"}}
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorBody describes an error. Code repeats the HTTP status.
+type ErrorBody struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// WriteError writes a JSON error envelope with status in response to r.
+func WriteError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+
+	if err := encodeJSON(w, r, ErrorResponse{
+		Error: ErrorBody{Code: status, Message: message},
+	}); err != nil {
+		// Error encoding response, but status already sent
+		return
+	}
+}
+
+// writeMethodNotAllowed rejects a request whose method is not in allow.
+func writeMethodNotAllowed(w http.ResponseWriter, r *http.Request, allow string) {
+	w.Header().Set("Allow", allow)
+	WriteError(w, r, http.StatusMethodNotAllowed, "Method not allowed")
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func decodeError(t *testing.T, rr *httptest.ResponseRecorder) ErrorResponse {
+	t.Helper()
+
+	if ct := rr.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected Content-Type application/json, got %s", ct)
+	}
+
+	var response ErrorResponse
+	dec := json.NewDecoder(rr.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&response); err != nil {
+		t.Fatalf("Failed to unmarshal error envelope: %v", err)
+	}
+	return response
+}
+
+func TestWriteErrorMethodNotAllowed(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/info", nil)
+	rr := httptest.NewRecorder()
+	Info("test-app", "1.0.0")(rr, req)
+
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status %d, got %d", http.StatusMethodNotAllowed, rr.Code)
+	}
+	if allow := rr.Header().Get("Allow"); allow != "GET" {
+		t.Errorf("Expected Allow header 'GET', got '%s'", allow)
+	}
+
+	response := decodeError(t, rr)
+	if response.Error.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected error code %d, got %d", http.StatusMethodNotAllowed, response.Error.Code)
+	}
+	if response.Error.Message != "Method not allowed" {
+		t.Errorf("Expected message 'Method not allowed', got '%s'", response.Error.Message)
+	}
+}
+
+func TestRecoveryMiddlewareWritesErrorEnvelope(t *testing.T) {
+	var logs bytes.Buffer
+	handler := RecoveryMiddleware(log.New(&logs, "", 0))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/info", nil))
+
+	if rr.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status %d, got %d", http.StatusInternalServerError, rr.Code)
+	}
+
+	response := decodeError(t, rr)
+	if response.Error.Code != http.StatusInternalServerError {
+		t.Errorf("Expected error code %d, got %d", http.StatusInternalServerError, response.Error.Code)
+	}
+	if response.Error.Message != "Internal server error" {
+		t.Errorf("Expected message 'Internal server error', got '%s'", response.Error.Message)
+	}
+
+	if !strings.Contains(logs.String(), "panic serving GET /api/info: boom") {
+		t.Errorf("Expected panic to be logged, got: %s", logs.String())
+	}
+}
+
+func TestRecoveryMiddlewareRepanicsAbortHandler(t *testing.T) {
+	handler := RecoveryMiddleware(log.New(&bytes.Buffer{}, "", 0))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	defer func() {
+		if rec := recover(); rec != http.ErrAbortHandler {
+			t.Errorf("Expected http.ErrAbortHandler to propagate, got %v", rec)
+		}
+	}()
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
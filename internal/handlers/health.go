@@ -1,19 +1,20 @@
 package handlers
 
 import (
-	"encoding/json"
 	"net/http"
 	"time"
 )
 
 // HealthResponse represents the health check response.
 type HealthResponse struct {
-	Status    string    `json:"status"`
-	Timestamp time.Time `json:"timestamp"`
-	Version   string    `json:"version,omitempty"`
+	Status    string        `json:"status"`
+	Timestamp time.Time     `json:"timestamp"`
+	Version   string        `json:"version,omitempty"`
+	Checks    []CheckResult `json:"checks,omitempty"`
 }
 
-// HealthCheck returns the application health status.
+// HealthCheck returns the application health status as JSON, or as a plain
+// "healthy" body when the client's Accept header prefers text/plain.
 //
 // GET /health
 //
@@ -21,10 +22,15 @@ type HealthResponse struct {
 //   - 200: Application is healthy
 //   - 503: Application has issues
 func HealthCheck(version string) http.HandlerFunc {
+	return MethodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: healthCheck(version),
+	})
+}
+
+func healthCheck(version string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			w.Header().Set("Allow", "GET")
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		if wantsPlainText(r) {
+			writePlainText(w, http.StatusOK, "healthy")
 			return
 		}
 
@@ -37,50 +43,61 @@ func HealthCheck(version string) http.HandlerFunc {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 
-		if err := json.NewEncoder(w).Encode(response); err != nil {
-			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		if err := encodeJSON(w, r, response); err != nil {
+			// Error encoding response, but status already sent
 			return
 		}
 	}
 }
 
 // ReadinessCheck returns whether the application is ready to serve traffic.
+// Every critical check in registry must pass; failed non-critical checks
+// report "degraded" with a 200. A nil registry has no checks. Like
+// HealthCheck, it answers in plain text when the client prefers text/plain.
 //
 // GET /ready
 //
 // Returns:
-//   - 200: Application is ready
-//   - 503: Application is not ready
-func ReadinessCheck() http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			w.Header().Set("Allow", "GET")
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-			return
-		}
-
-		// Add actual readiness checks here (database connectivity, etc.)
-		ready := true
+//   - 200: Application is ready, or degraded
+//   - 503: A critical check failed
+func ReadinessCheck(registry *ReadinessRegistry) http.HandlerFunc {
+	return MethodHandler(map[string]http.HandlerFunc{
+		http.MethodGet: readinessCheck(registry),
+	})
+}
 
-		if !ready {
-			w.WriteHeader(http.StatusServiceUnavailable)
-			_, err := w.Write([]byte("Not ready"))
-			if err != nil {
-				// Error writing response, but we've already set status
-				return
-			}
-			return
+func readinessCheck(registry *ReadinessRegistry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var results []CheckResult
+		if registry != nil {
+			results = registry.Run(r.Context())
 		}
 
 		response := HealthResponse{
 			Status:    "ready",
 			Timestamp: time.Now().UTC(),
+			Checks:    results,
+		}
+		status := http.StatusOK
+
+		switch {
+		case !criticalPassed(results):
+			response.Status = "not ready"
+			status = http.StatusServiceUnavailable
+		case !allPassed(results):
+			// Non-critical dependencies are down; keep serving traffic
+			response.Status = "degraded"
+		}
+
+		if wantsPlainText(r) {
+			writePlainText(w, status, response.Status)
+			return
 		}
 
 		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
+		w.WriteHeader(status)
 
-		err := json.NewEncoder(w).Encode(response)
+		err := encodeJSON(w, r, response)
 		if err != nil {
 			// Error encoding response, but status already sent
 			return
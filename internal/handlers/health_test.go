@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -63,7 +66,7 @@ func TestHealthCheckInvalidMethod(t *testing.T) {
 }
 
 func TestReadinessCheck(t *testing.T) {
-	handler := ReadinessCheck()
+	handler := ReadinessCheck(nil)
 
 	req, err := http.NewRequest("GET", "/ready", nil)
 	if err != nil {
@@ -88,7 +91,7 @@ func TestReadinessCheck(t *testing.T) {
 }
 
 func TestReadinessCheckInvalidMethod(t *testing.T) {
-	handler := ReadinessCheck()
+	handler := ReadinessCheck(nil)
 
 	req, err := http.NewRequest("DELETE", "/ready", nil)
 	if err != nil {
@@ -102,3 +105,63 @@ func TestReadinessCheckInvalidMethod(t *testing.T) {
 		t.Errorf("Expected status code %d, got %d", http.StatusMethodNotAllowed, status)
 	}
 }
+
+func TestHealthCheckContentNegotiation(t *testing.T) {
+	tests := []struct {
+		name        string
+		accept      string
+		contentType string
+	}{
+		{"json", "application/json", "application/json"},
+		{"plain text", "text/plain", "text/plain; charset=utf-8"},
+		{"no accept header", "", "application/json"},
+		{"browser default", "text/html,application/xhtml+xml,*/*;q=0.8", "application/json"},
+		{"text preferred by quality", "application/json;q=0.5, text/plain", "text/plain; charset=utf-8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/health", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+
+			rr := httptest.NewRecorder()
+			HealthCheck("1.0.0")(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Errorf("Expected status code %d, got %d", http.StatusOK, rr.Code)
+			}
+			if contentType := rr.Header().Get("Content-Type"); contentType != tt.contentType {
+				t.Errorf("Expected Content-Type '%s', got '%s'", tt.contentType, contentType)
+			}
+
+			if tt.contentType == "application/json" {
+				var response HealthResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Errorf("Failed to unmarshal response: %v", err)
+				}
+			} else if body := strings.TrimSpace(rr.Body.String()); body != "healthy" {
+				t.Errorf("Expected body 'healthy', got '%s'", body)
+			}
+		})
+	}
+}
+
+func TestReadinessCheckPlainText(t *testing.T) {
+	registry := NewReadinessRegistry(1)
+	registry.Register("db", func(ctx context.Context) error { return errors.New("down") })
+
+	req := httptest.NewRequest(http.MethodGet, "/ready", nil)
+	req.Header.Set("Accept", "text/plain")
+
+	rr := httptest.NewRecorder()
+	ReadinessCheck(registry)(rr, req)
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status code %d, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+	if body := strings.TrimSpace(rr.Body.String()); body != "not ready" {
+		t.Errorf("Expected body 'not ready', got '%s'", body)
+	}
+}
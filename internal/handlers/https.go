@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// HTTPSRedirectMiddleware redirects plain-HTTP requests to their https://
+// equivalent with a 308, so the method and body are preserved. A request
+// counts as HTTPS when it arrived over TLS or, with trustProxy set, when a
+// trusted proxy reports X-Forwarded-Proto: https. Requests for the exempt
+// paths, typically health checks probed over plain HTTP, are served as is.
+func HTTPSRedirectMiddleware(trustProxy bool, exempt ...string) func(http.Handler) http.Handler {
+	skip := make(map[string]bool, len(exempt))
+	for _, path := range exempt {
+		skip[path] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if skip[r.URL.Path] || isHTTPS(r, trustProxy) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// The HTTPS listener is on the default port, so drop any port
+			// the plain-HTTP request was addressed to
+			host := r.Host
+			if h, _, err := net.SplitHostPort(host); err == nil {
+				host = h
+			}
+
+			http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+		})
+	}
+}
+
+// isHTTPS reports whether r reached the client-facing endpoint over TLS.
+// X-Forwarded-Proto is only believed from a trusted proxy, since any client
+// can send it.
+func isHTTPS(r *http.Request, trustProxy bool) bool {
+	if r.TLS != nil {
+		return true
+	}
+	if !trustProxy || !trustedProxy(remoteIP(r)) {
+		return false
+	}
+
+	// A chain of proxies appends to the header; the first entry is the
+	// scheme the client used
+	proto, _, _ := strings.Cut(r.Header.Get("X-Forwarded-Proto"), ",")
+	return strings.EqualFold(strings.TrimSpace(proto), "https")
+}
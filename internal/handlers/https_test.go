@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPSRedirectMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		trustProxy bool
+		target     string
+		remote     string
+		proto      string
+		tls        bool
+		code       int
+		location   string
+	}{
+		{"plain http", false, "http://example.com:8080/api/info?x=1", "203.0.113.7:1234", "", false, http.StatusPermanentRedirect, "https://example.com/api/info?x=1"},
+		{"tls", false, "https://example.com/api/info", "203.0.113.7:1234", "", true, http.StatusOK, ""},
+		{"https from trusted proxy", true, "http://example.com/api/info", "10.0.0.2:1234", "https", false, http.StatusOK, ""},
+		{"proxy chain", true, "http://example.com/api/info", "10.0.0.2:1234", "HTTPS, http", false, http.StatusOK, ""},
+		{"http from trusted proxy", true, "http://example.com/api/info", "10.0.0.2:1234", "http", false, http.StatusPermanentRedirect, "https://example.com/api/info"},
+		{"proxy header without trust", false, "http://example.com/api/info", "10.0.0.2:1234", "https", false, http.StatusPermanentRedirect, "https://example.com/api/info"},
+		{"proxy header from client", true, "http://example.com/api/info", "203.0.113.7:1234", "https", false, http.StatusPermanentRedirect, "https://example.com/api/info"},
+		{"exempt path", false, "http://example.com/health", "203.0.113.7:1234", "", false, http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.target, nil)
+			req.RemoteAddr = tt.remote
+			if tt.proto != "" {
+				req.Header.Set("X-Forwarded-Proto", tt.proto)
+			}
+			if tt.tls {
+				req.TLS = &tls.ConnectionState{}
+			} else {
+				req.TLS = nil
+			}
+			rr := httptest.NewRecorder()
+
+			HTTPSRedirectMiddleware(tt.trustProxy, "/health")(next).ServeHTTP(rr, req)
+
+			if rr.Code != tt.code {
+				t.Errorf("Expected status %d, got %d", tt.code, rr.Code)
+			}
+			if got := rr.Header().Get("Location"); got != tt.location {
+				t.Errorf("Expected Location %q, got %q", tt.location, got)
+			}
+		})
+	}
+}
+
+func TestRouterForceHTTPSExemptsHealthChecks(t *testing.T) {
+	router := NewRouter(RouterOptions{Name: "test-app", Version: "1.0.0", ForceHTTPS: true, BasePath: "/svc"})
+
+	for path, want := range map[string]int{
+		"/svc/health":   http.StatusOK,
+		"/svc/ready":    http.StatusOK,
+		"/svc/api/info": http.StatusPermanentRedirect,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com"+path, nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != want {
+			t.Errorf("Expected %s status %d, got %d", path, want, rr.Code)
+		}
+	}
+
+	if err := router.SelfCheck(); err != nil {
+		t.Errorf("SelfCheck() returned error: %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+	"runtime"
+)
+
+// InfoResponse represents the application info response. GoVersion, OS and
+// Arch identify which binary variant is deployed.
+type InfoResponse struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	GoVersion string `json:"go_version"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+}
+
+// Info returns basic application information and the platform the binary
+// was built for.
+//
+// GET /api/info
+//
+// Returns:
+//   - 200: Application name, version and platform
+func Info(name, version string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeMethodNotAllowed(w, r, "GET")
+			return
+		}
+
+		response := InfoResponse{
+			Name:      name,
+			Version:   version,
+			GoVersion: runtime.Version(),
+			OS:        runtime.GOOS,
+			Arch:      runtime.GOARCH,
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := encodeJSON(w, r, response); err != nil {
+			// Error encoding response, but status already sent
+			return
+		}
+	}
+}
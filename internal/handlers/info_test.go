@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+)
+
+func TestInfo(t *testing.T) {
+	handler := Info("test-app", "1.0.0")
+
+	req, err := http.NewRequest("GET", "/api/info", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	handler(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, status)
+	}
+
+	var response InfoResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Errorf("Failed to unmarshal response: %v", err)
+	}
+
+	if response.Name != "test-app" || response.Version != "1.0.0" {
+		t.Errorf("Expected name 'test-app' and version '1.0.0', got %+v", response)
+	}
+
+	if response.GoVersion != runtime.Version() || response.OS != runtime.GOOS || response.Arch != runtime.GOARCH {
+		t.Errorf("Expected platform %s %s/%s, got %+v", runtime.Version(), runtime.GOOS, runtime.GOARCH, response)
+	}
+}
@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/your-org/go-template-project/internal/config"
+	"github.com/your-org/go-template-project/internal/metrics"
+	"github.com/your-org/go-template-project/internal/testutil"
+	"github.com/your-org/go-template-project/internal/tracing"
+)
+
+const integrationAPIKey = "integration-key"
+
+// integrationServer serves the full router, with every optional middleware
+// enabled, from an in-process HTTP server.
+type integrationServer struct {
+	*httptest.Server
+	spans *tracing.Recorder
+}
+
+func newIntegrationServer(t *testing.T) *integrationServer {
+	t.Helper()
+
+	maintenance := &Maintenance{}
+	readiness := NewReadinessRegistry(2)
+	readiness.Register("maintenance", maintenance.Check)
+
+	spans := &tracing.Recorder{}
+	router := NewRouter(RouterOptions{
+		Name:           "integration-app",
+		Version:        "1.2.3",
+		Commit:         "abc123",
+		Readiness:      readiness,
+		Metrics:        metrics.New(),
+		Maintenance:    maintenance,
+		AdminAPIKey:    integrationAPIKey,
+		AccessLogMode:  config.AccessLogErrors,
+		HandlerTimeout: 200 * time.Millisecond,
+		Tracer:         tracing.New(spans),
+		Routes: func(mux *http.ServeMux) {
+			mux.HandleFunc("/test/panic", func(w http.ResponseWriter, r *http.Request) {
+				panic("integration panic")
+			})
+			mux.HandleFunc("/test/slow", func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(5 * time.Second):
+				}
+			})
+		},
+	})
+
+	if err := router.SelfCheck(); err != nil {
+		t.Fatalf("SelfCheck() returned error: %v", err)
+	}
+
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+	return &integrationServer{Server: srv, spans: spans}
+}
+
+// do sends a request and returns the response with its body read.
+func (s *integrationServer) do(t *testing.T, method, path, body string, header http.Header) (*http.Response, []byte) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, s.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	resp, err := s.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read %s %s response: %v", method, path, err)
+	}
+	return resp, data
+}
+
+func TestIntegrationEndpoints(t *testing.T) {
+	srv := newIntegrationServer(t)
+
+	tests := []struct {
+		path        string
+		status      int
+		contentType string
+		json        map[string]any // asserted structurally when set
+		contains    string
+	}{
+		{"/health", http.StatusOK, "application/json",
+			map[string]any{"status": "healthy", "version": "1.2.3", "timestamp": testutil.Present}, ""},
+		{"/ready", http.StatusOK, "application/json",
+			map[string]any{"status": "ready", "checks": testutil.Present}, ""},
+		{"/api/info", http.StatusOK, "application/json",
+			map[string]any{"name": "integration-app", "version": "1.2.3"}, ""},
+		{"/version", http.StatusOK, "application/json", map[string]any{"commit": "abc123"}, ""},
+		{"/metrics.json", http.StatusOK, "application/json", map[string]any{"total_requests": testutil.Present}, ""},
+		{"/openapi.json", http.StatusOK, "application/json", nil, `"/version"`},
+		{"/does-not-exist", http.StatusNotFound, "text/plain; charset=utf-8", nil, "404 page not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			resp, body := srv.do(t, http.MethodGet, tt.path, "", nil)
+
+			if resp.StatusCode != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, resp.StatusCode)
+			}
+			if ct := resp.Header.Get("Content-Type"); ct != tt.contentType {
+				t.Errorf("Expected Content-Type %s, got %s", tt.contentType, ct)
+			}
+			if tt.json != nil {
+				testutil.AssertJSON(t, body, tt.json)
+			} else if !strings.Contains(string(body), tt.contains) {
+				t.Errorf("Expected body to contain %s, got: %s", tt.contains, body)
+			}
+			if resp.Header.Get(RequestIDHeader) == "" {
+				t.Errorf("Expected %s response header", RequestIDHeader)
+			}
+		})
+	}
+}
+
+func TestIntegrationErrorEnvelopes(t *testing.T) {
+	srv := newIntegrationServer(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		status int
+	}{
+		{"method not allowed", http.MethodDelete, "/api/info", http.StatusMethodNotAllowed},
+		{"missing api key", http.MethodGet, "/admin/maintenance", http.StatusUnauthorized},
+		{"panic is recovered", http.MethodGet, "/test/panic", http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, body := srv.do(t, tt.method, tt.path, "", nil)
+
+			if resp.StatusCode != tt.status {
+				t.Fatalf("Expected status %d, got %d", tt.status, resp.StatusCode)
+			}
+
+			var envelope ErrorResponse
+			if err := json.Unmarshal(body, &envelope); err != nil {
+				t.Fatalf("Expected JSON error envelope, got %s", body)
+			}
+			if envelope.Error.Code != tt.status || envelope.Error.Message == "" {
+				t.Errorf("Expected code %d with a message, got %+v", tt.status, envelope.Error)
+			}
+		})
+	}
+
+	// The server keeps serving after the panic
+	if resp, _ := srv.do(t, http.MethodGet, "/health", "", nil); resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected /health to answer after a panic, got %d", resp.StatusCode)
+	}
+}
+
+func TestIntegrationHandlerTimeout(t *testing.T) {
+	srv := newIntegrationServer(t)
+
+	resp, body := srv.do(t, http.MethodGet, "/test/slow", "", nil)
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d, got %d", http.StatusServiceUnavailable, resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected a JSON timeout envelope, got Content-Type %q: %s", ct, body)
+	}
+}
+
+func TestIntegrationMaintenanceDrainsReadiness(t *testing.T) {
+	srv := newIntegrationServer(t)
+	auth := http.Header{"X-Api-Key": {integrationAPIKey}}
+
+	resp, _ := srv.do(t, http.MethodPost, "/admin/maintenance", `{"enabled": true}`, auth)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected maintenance toggle to succeed, got %d", resp.StatusCode)
+	}
+
+	if resp, _ := srv.do(t, http.MethodGet, "/ready", "", nil); resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected /ready to fail in maintenance, got %d", resp.StatusCode)
+	}
+	if resp, _ := srv.do(t, http.MethodGet, "/health", "", nil); resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected /health to stay healthy in maintenance, got %d", resp.StatusCode)
+	}
+
+	srv.do(t, http.MethodPost, "/admin/maintenance", `{"enabled": false}`, auth)
+	if resp, _ := srv.do(t, http.MethodGet, "/ready", "", nil); resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected /ready to recover after maintenance, got %d", resp.StatusCode)
+	}
+}
+
+func TestIntegrationMetricsAndTracing(t *testing.T) {
+	srv := newIntegrationServer(t)
+
+	srv.do(t, http.MethodGet, "/api/info", "", http.Header{
+		"Traceparent": {"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+	})
+	srv.do(t, http.MethodGet, "/test/panic", "", nil)
+
+	_, body := srv.do(t, http.MethodGet, "/metrics.json", "", nil)
+	var snapshot metrics.Snapshot
+	if err := json.Unmarshal(body, &snapshot); err != nil {
+		t.Fatalf("Failed to unmarshal metrics: %v", err)
+	}
+
+	counts := make(map[string]metrics.RouteMetrics)
+	for _, route := range snapshot.Requests {
+		counts[route.Path] = route
+	}
+	if counts["/api/info"].Count != 1 {
+		t.Errorf("Expected 1 /api/info request, got %d", counts["/api/info"].Count)
+	}
+	if counts["/test/panic"].Errors != 1 {
+		t.Errorf("Expected the recovered panic to count as an error, got %d", counts["/test/panic"].Errors)
+	}
+
+	var traced bool
+	for _, span := range srv.spans.Spans() {
+		if span.Attributes["url.path"] == "/api/info" {
+			traced = span.Context.TraceID.String() == "4bf92f3577b34da6a3ce929d0e0e4736"
+		}
+	}
+	if !traced {
+		t.Error("Expected /api/info span to continue the incoming trace")
+	}
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// prettyJSONKey marks a request whose JSON responses are indented; see
+// RouterOptions.PrettyJSON.
+type prettyJSONKey struct{}
+
+// prettyJSONMiddleware marks every request so its JSON responses, including
+// WriteError envelopes, are indented.
+func prettyJSONMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), prettyJSONKey{}, true)))
+	})
+}
+
+// encodeJSON writes v to w as JSON followed by a newline, indented when r
+// was served by a router built with PrettyJSON.
+func encodeJSON(w io.Writer, r *http.Request, v any) error {
+	enc := json.NewEncoder(w)
+	if pretty, _ := r.Context().Value(prettyJSONKey{}).(bool); pretty {
+		enc.SetIndent("", "  ")
+	}
+	return enc.Encode(v)
+}
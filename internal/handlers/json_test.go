@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPrettyJSON(t *testing.T) {
+	// Routers with different settings in one process don't affect each other
+	routers := map[bool]http.Handler{
+		false: NewRouter(RouterOptions{Name: "test-app", Version: "1.0.0"}),
+		true:  NewRouter(RouterOptions{Name: "test-app", Version: "1.0.0", PrettyJSON: true}),
+	}
+
+	tests := []struct {
+		name   string
+		method string
+	}{
+		{"info", http.MethodGet},
+		{"error", http.MethodPost},
+	}
+
+	for _, tt := range tests {
+		for _, pretty := range []bool{false, true} {
+			rr := httptest.NewRecorder()
+			routers[pretty].ServeHTTP(rr, httptest.NewRequest(tt.method, "/api/info", nil))
+
+			// Compact output is a single line; indented output nests fields
+			body := strings.TrimSuffix(rr.Body.String(), "\n")
+			if indented := strings.Contains(body, "\n  \""); indented != pretty {
+				t.Errorf("%s: expected indented=%t, got body:\n%s", tt.name, pretty, body)
+			}
+		}
+	}
+}
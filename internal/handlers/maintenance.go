@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+)
+
+// ErrMaintenance is reported by Maintenance.Check while maintenance mode is on.
+var ErrMaintenance = errors.New("maintenance mode enabled")
+
+// Maintenance is a runtime toggle that takes an instance out of rotation.
+// Register Check on the readiness registry so /ready returns 503 while
+// enabled; /health is unaffected so orchestrators don't restart the instance.
+type Maintenance struct {
+	enabled atomic.Bool
+}
+
+// Set turns maintenance mode on or off.
+func (m *Maintenance) Set(enabled bool) {
+	m.enabled.Store(enabled)
+}
+
+// Enabled reports whether maintenance mode is on.
+func (m *Maintenance) Enabled() bool {
+	return m.enabled.Load()
+}
+
+// Check is a ReadinessFunc that fails while maintenance mode is on.
+func (m *Maintenance) Check(ctx context.Context) error {
+	if m.Enabled() {
+		return ErrMaintenance
+	}
+	return nil
+}
+
+// MaintenanceRequest toggles maintenance mode.
+type MaintenanceRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+// MaintenanceResponse reports the maintenance mode state.
+type MaintenanceResponse struct {
+	Enabled bool `json:"enabled"`
+}
+
+// MaintenanceToggle reads or sets maintenance mode. Requests must carry the
+// admin API key in the X-API-Key header.
+//
+// GET /admin/maintenance
+// POST /admin/maintenance {"enabled": true}
+//
+// Returns:
+//   - 200: Current maintenance state
+//   - 400: Malformed request body
+//   - 401: Missing or wrong API key
+func MaintenanceToggle(m *Maintenance, apiKey string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPost {
+			writeMethodNotAllowed(w, r, "GET, POST")
+			return
+		}
+
+		provided := r.Header.Get("X-API-Key")
+		if apiKey == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(apiKey)) != 1 {
+			WriteError(w, r, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		if r.Method == http.MethodPost {
+			var req MaintenanceRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				WriteError(w, r, http.StatusBadRequest, "Invalid request body")
+				return
+			}
+			m.Set(req.Enabled)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := encodeJSON(w, r, MaintenanceResponse{Enabled: m.Enabled()}); err != nil {
+			// Error encoding response, but status already sent
+			return
+		}
+	}
+}
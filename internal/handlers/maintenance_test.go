@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMaintenanceFlipsReadinessOnly(t *testing.T) {
+	maintenance := &Maintenance{}
+	registry := NewReadinessRegistry(1)
+	registry.Register("maintenance", maintenance.Check)
+
+	router := NewRouter(RouterOptions{Name: "test-app", Version: "1.0.0", Readiness: registry})
+
+	status := func(path string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	if code := status("/ready"); code != http.StatusOK {
+		t.Errorf("Expected /ready %d before maintenance, got %d", http.StatusOK, code)
+	}
+
+	maintenance.Set(true)
+	if code := status("/ready"); code != http.StatusServiceUnavailable {
+		t.Errorf("Expected /ready %d during maintenance, got %d", http.StatusServiceUnavailable, code)
+	}
+	if code := status("/health"); code != http.StatusOK {
+		t.Errorf("Expected /health %d during maintenance, got %d", http.StatusOK, code)
+	}
+
+	maintenance.Set(false)
+	if code := status("/ready"); code != http.StatusOK {
+		t.Errorf("Expected /ready %d after maintenance, got %d", http.StatusOK, code)
+	}
+}
+
+func TestMaintenanceToggle(t *testing.T) {
+	maintenance := &Maintenance{}
+	router := NewRouter(RouterOptions{
+		Name:        "test-app",
+		Version:     "1.0.0",
+		Maintenance: maintenance,
+		AdminAPIKey: "secret",
+	})
+
+	tests := []struct {
+		name    string
+		method  string
+		key     string
+		body    string
+		code    int
+		enabled bool
+	}{
+		{"missing key", http.MethodPost, "", `{"enabled":true}`, http.StatusUnauthorized, false},
+		{"wrong key", http.MethodPost, "nope", `{"enabled":true}`, http.StatusUnauthorized, false},
+		{"bad body", http.MethodPost, "secret", `{`, http.StatusBadRequest, false},
+		{"enable", http.MethodPost, "secret", `{"enabled":true}`, http.StatusOK, true},
+		{"read state", http.MethodGet, "secret", "", http.StatusOK, true},
+		{"disable", http.MethodPost, "secret", `{"enabled":false}`, http.StatusOK, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/admin/maintenance", strings.NewReader(tt.body))
+			if tt.key != "" {
+				req.Header.Set("X-API-Key", tt.key)
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			if rr.Code != tt.code {
+				t.Errorf("Expected status %d, got %d", tt.code, rr.Code)
+			}
+			if maintenance.Enabled() != tt.enabled {
+				t.Errorf("Expected maintenance enabled=%t, got %t", tt.enabled, maintenance.Enabled())
+			}
+		})
+	}
+}
+
+func TestMaintenanceToggleNotRegisteredWithoutKey(t *testing.T) {
+	router := NewRouter(RouterOptions{Name: "test-app", Version: "1.0.0", Maintenance: &Maintenance{}})
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/maintenance", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d without an admin key, got %d", http.StatusNotFound, rr.Code)
+	}
+}
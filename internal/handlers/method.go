@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// MethodHandler dispatches requests to the handler registered for their
+// method. Any other method gets a 405 with an Allow header listing the
+// registered methods in sorted order.
+func MethodHandler(handlers map[string]http.HandlerFunc) http.HandlerFunc {
+	methods := make([]string, 0, len(handlers))
+	for method := range handlers {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	allow := strings.Join(methods, ", ")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		handler, ok := handlers[r.Method]
+		if !ok {
+			writeMethodNotAllowed(w, r, allow)
+			return
+		}
+		handler(w, r)
+	}
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMethodHandler(t *testing.T) {
+	handler := MethodHandler(map[string]http.HandlerFunc{
+		http.MethodPost: func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		},
+		http.MethodGet: func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		},
+	})
+
+	tests := []struct {
+		method string
+		status int
+	}{
+		{http.MethodGet, http.StatusOK},
+		{http.MethodPost, http.StatusCreated},
+		{http.MethodDelete, http.StatusMethodNotAllowed},
+		{http.MethodHead, http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(tt.method, "/", nil))
+
+			if rr.Code != tt.status {
+				t.Errorf("Expected status code %d, got %d", tt.status, rr.Code)
+			}
+
+			if tt.status != http.StatusMethodNotAllowed {
+				return
+			}
+			if allow := rr.Header().Get("Allow"); allow != "GET, POST" {
+				t.Errorf("Expected Allow header 'GET, POST', got '%s'", allow)
+			}
+			if ct := rr.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Expected JSON error envelope, got Content-Type '%s'", ct)
+			}
+		})
+	}
+}
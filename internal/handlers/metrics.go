@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/your-org/go-template-project/internal/metrics"
+)
+
+// unmatchedPath labels 404 and redirect responses so arbitrary request
+// paths can't grow the metrics without bound. ServeMux answers
+// non-canonical paths such as /a//b with a redirect, so redirects are as
+// open-ended as 404s.
+const unmatchedPath = "unmatched"
+
+// otherMethod labels requests whose method isn't a standard HTTP method, so
+// clients can't grow the metrics by sending made-up methods.
+const otherMethod = "OTHER"
+
+// metricsMethods are the methods recorded under their own label.
+var metricsMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodConnect: true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+}
+
+// MetricsMiddleware records the method, path, status and duration of every
+// request into collector. 404 and 3xx responses are recorded under
+// unmatchedPath rather than the request path, and non-standard methods
+// under otherMethod.
+func MetricsMiddleware(collector *metrics.Collector) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(rec, r)
+
+			path := r.URL.Path
+			if rec.status == http.StatusNotFound || (rec.status >= 300 && rec.status < 400) {
+				path = unmatchedPath
+			}
+			method := r.Method
+			if !metricsMethods[method] {
+				method = otherMethod
+			}
+			collector.Record(method, path, rec.status, time.Since(start))
+		})
+	}
+}
+
+// MetricsJSON returns a snapshot of request and runtime metrics as JSON, for
+// environments that don't scrape Prometheus.
+//
+// GET /metrics.json
+//
+// Returns:
+//   - 200: Current metrics snapshot
+func MetricsJSON(collector *metrics.Collector) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeMethodNotAllowed(w, r, "GET")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := encodeJSON(w, r, collector.Snapshot()); err != nil {
+			// Error encoding response, but status already sent
+			return
+		}
+	}
+}
+
+// Prometheus exposes request counters and latency histograms in the
+// Prometheus text format.
+//
+// GET /metrics
+//
+// Returns:
+//   - 200: Metrics in the Prometheus exposition format
+func Prometheus(collector *metrics.Collector) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeMethodNotAllowed(w, r, "GET")
+			return
+		}
+
+		w.Header().Set("Content-Type", metrics.PrometheusContentType)
+		w.WriteHeader(http.StatusOK)
+
+		if err := collector.WritePrometheus(w); err != nil {
+			// Error writing response, but status already sent
+			return
+		}
+	}
+}
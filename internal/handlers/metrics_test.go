@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/your-org/go-template-project/internal/metrics"
+)
+
+func TestMetricsJSONCountsRequests(t *testing.T) {
+	router := NewRouter(RouterOptions{
+		Name:    "test-app",
+		Version: "1.0.0",
+		Metrics: metrics.New(),
+	})
+
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/does-not-exist", nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	req = httptest.NewRequest(http.MethodGet, "/metrics.json", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	var snap metrics.Snapshot
+	if err := json.Unmarshal(rr.Body.Bytes(), &snap); err != nil {
+		t.Fatalf("Failed to unmarshal metrics: %v", err)
+	}
+
+	counts := map[string]int64{}
+	for _, route := range snap.Requests {
+		counts[route.Path] = route.Count
+	}
+
+	if counts["/health"] != 3 {
+		t.Errorf("Expected 3 /health requests, got %d", counts["/health"])
+	}
+	if counts[unmatchedPath] != 1 {
+		t.Errorf("Expected 1 unmatched request, got %d", counts[unmatchedPath])
+	}
+	if snap.Goroutines == 0 {
+		t.Error("Expected goroutine count to be reported")
+	}
+}
+
+func TestMetricsCollapseRedirectPaths(t *testing.T) {
+	collector := metrics.New()
+	router := NewRouter(RouterOptions{Name: "test-app", Version: "1.0.0", Metrics: collector})
+
+	// ServeMux redirects each non-canonical path to its clean form
+	for _, path := range []string{"/a//b", "/x/../y", "/c//d", "/e/./f", "//g"} {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		if rr.Code < 300 || rr.Code > 399 {
+			t.Fatalf("Expected %s to be redirected, got %d", path, rr.Code)
+		}
+	}
+
+	paths := map[string]int64{}
+	for _, route := range collector.Snapshot().Requests {
+		paths[route.Path] += route.Count
+	}
+	if len(paths) != 1 || paths[unmatchedPath] != 5 {
+		t.Errorf("Expected all 5 redirects under %q, got %v", unmatchedPath, paths)
+	}
+}
+
+func TestMetricsCollapseUnknownMethods(t *testing.T) {
+	collector := metrics.New()
+	router := NewRouter(RouterOptions{Name: "test-app", Version: "1.0.0", Metrics: collector})
+
+	for _, method := range []string{"FOO", "X1", "get", http.MethodGet} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, "/health", nil))
+	}
+
+	methods := map[string]int64{}
+	for _, route := range collector.Snapshot().Requests {
+		methods[route.Method] += route.Count
+	}
+	want := map[string]int64{http.MethodGet: 1, otherMethod: 3}
+	if !reflect.DeepEqual(methods, want) {
+		t.Errorf("Expected methods %v, got %v", want, methods)
+	}
+}
+
+func TestPrometheusExposesLatencyHistogram(t *testing.T) {
+	router := NewRouter(RouterOptions{
+		Name:    "test-app",
+		Version: "1.0.0",
+		Metrics: metrics.New(),
+	})
+
+	for i := 0; i < 3; i++ {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/info", nil))
+	}
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status code %d, got %d", http.StatusOK, rr.Code)
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != metrics.PrometheusContentType {
+		t.Errorf("Expected Content-Type %s, got %s", metrics.PrometheusContentType, ct)
+	}
+
+	body := rr.Body.String()
+	for _, line := range []string{
+		`http_requests_total{method="GET",path="/api/info"} 3`,
+		`http_request_duration_seconds_bucket{method="GET",path="/api/info",le="+Inf"} 3`,
+		`http_request_duration_seconds_count{method="GET",path="/api/info"} 3`,
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("Expected /metrics to contain %q\n%s", line, body)
+		}
+	}
+	if !strings.Contains(body, `http_request_duration_seconds_sum{method="GET",path="/api/info"}`) {
+		t.Errorf("Expected latency sum for /api/info\n%s", body)
+	}
+}
+
+func TestPrometheusExposesCustomCounter(t *testing.T) {
+	collector := metrics.New()
+	signups := collector.NewCounter("app_signups_total", "Accounts created.")
+
+	router := NewRouter(RouterOptions{
+		Name:    "test-app",
+		Version: "1.0.0",
+		Metrics: collector,
+		Routes: func(mux *http.ServeMux) {
+			mux.HandleFunc("/signup", func(w http.ResponseWriter, r *http.Request) {
+				signups.Inc()
+				w.WriteHeader(http.StatusCreated)
+			})
+		},
+	})
+
+	for i := 0; i < 2; i++ {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/signup", nil))
+	}
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	for _, line := range []string{
+		"# HELP app_signups_total Accounts created.",
+		"# TYPE app_signups_total counter",
+		"app_signups_total 2",
+	} {
+		if !strings.Contains(rr.Body.String(), line+"\n") {
+			t.Errorf("Expected /metrics to contain %q, got:\n%s", line, rr.Body.String())
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/your-org/go-template-project/internal/config"
+)
+
+// statusRecorder captures the status code written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// AccessLogMiddleware logs one line per request according to mode:
+//   - config.AccessLogAll: every request
+//   - config.AccessLogErrors: only 4xx and 5xx responses
+//   - config.AccessLogNone: nothing
+func AccessLogMiddleware(mode string, logger *log.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if mode == config.AccessLogNone {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(rec, r)
+
+			if mode == config.AccessLogErrors && rec.status < http.StatusBadRequest {
+				return
+			}
+
+			logger.Printf("%s %s %s %d %s", ClientIP(r), r.Method, r.URL.Path, rec.status, time.Since(start))
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/your-org/go-template-project/internal/config"
+)
+
+// statusHandler responds with the status code given in the "status" path.
+func statusHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/fail", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	return mux
+}
+
+func TestAccessLogMiddlewareModes(t *testing.T) {
+	tests := []struct {
+		mode       string
+		wantOKLog  bool
+		wantErrLog bool
+	}{
+		{config.AccessLogAll, true, true},
+		{config.AccessLogErrors, false, true},
+		{config.AccessLogNone, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := log.New(&buf, "", 0)
+			handler := AccessLogMiddleware(tt.mode, logger)(statusHandler())
+
+			for _, path := range []string{"/ok", "/fail"} {
+				req := httptest.NewRequest(http.MethodGet, path, nil)
+				handler.ServeHTTP(httptest.NewRecorder(), req)
+			}
+
+			output := buf.String()
+			if got := strings.Contains(output, "GET /ok 200"); got != tt.wantOKLog {
+				t.Errorf("200 logged = %t, want %t; output: %q", got, tt.wantOKLog, output)
+			}
+			if got := strings.Contains(output, "GET /fail 500"); got != tt.wantErrLog {
+				t.Errorf("500 logged = %t, want %t; output: %q", got, tt.wantErrLog, output)
+			}
+		})
+	}
+}
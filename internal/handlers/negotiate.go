@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// wantsPlainText reports whether the request's Accept header prefers
+// text/plain over application/json. A missing Accept header, or one that
+// rates both equally, selects JSON.
+func wantsPlainText(r *http.Request) bool {
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return false
+	}
+
+	var jsonQ, textQ float64
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+		q := acceptQuality(params)
+
+		switch mediaType {
+		case "application/json", "application/*":
+			jsonQ = max(jsonQ, q)
+		case "text/plain", "text/*":
+			textQ = max(textQ, q)
+		case "*/*":
+			jsonQ = max(jsonQ, q)
+			textQ = max(textQ, q)
+		}
+	}
+
+	return textQ > jsonQ
+}
+
+// acceptQuality extracts the q parameter from an Accept media range's
+// parameters, defaulting to 1.
+func acceptQuality(params string) float64 {
+	for _, param := range strings.Split(params, ";") {
+		key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok || strings.TrimSpace(key) != "q" {
+			continue
+		}
+		if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+			return q
+		}
+	}
+	return 1
+}
+
+// writePlainText writes body as a text/plain response with status.
+func writePlainText(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(body + "\n"))
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+)
+
+// OpenAPIDocument is a minimal OpenAPI 3 document describing the server's
+// endpoints. Routes add themselves with AddOperation as they are registered.
+type OpenAPIDocument struct {
+	OpenAPI string              `json:"openapi"`
+	Info    OpenAPIInfo         `json:"info"`
+	Paths   map[string]PathItem `json:"paths"`
+}
+
+// OpenAPIInfo identifies the API.
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem maps lowercase HTTP methods to their operations.
+type PathItem map[string]Operation
+
+// Operation describes a single method on a path.
+type Operation struct {
+	Summary   string              `json:"summary,omitempty"`
+	Responses map[string]Response `json:"responses"`
+}
+
+// Response describes one status code of an operation.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema for a response content type.
+type MediaType struct {
+	Schema Schema `json:"schema"`
+}
+
+// Schema is the subset of JSON Schema used by the spec.
+type Schema struct {
+	Type       string            `json:"type,omitempty"`
+	Format     string            `json:"format,omitempty"`
+	Properties map[string]Schema `json:"properties,omitempty"`
+	Items      *Schema           `json:"items,omitempty"`
+}
+
+// OpenAPISpec returns a document describing the core endpoints: /health,
+// /ready and /api/info.
+func OpenAPISpec(name, version string) *OpenAPIDocument {
+	doc := &OpenAPIDocument{
+		OpenAPI: "3.0.3",
+		Info:    OpenAPIInfo{Title: name, Version: version},
+		Paths:   make(map[string]PathItem),
+	}
+
+	doc.AddOperation(http.MethodGet, "/health", Operation{
+		Summary: "Application health status",
+		Responses: map[string]Response{
+			"200": negotiatedResponse("Application is healthy", healthSchema),
+			"503": negotiatedResponse("Application has issues", healthSchema),
+		},
+	})
+	doc.AddOperation(http.MethodGet, "/ready", Operation{
+		Summary: "Readiness to serve traffic",
+		Responses: map[string]Response{
+			"200": negotiatedResponse("Application is ready", healthSchema),
+			"503": negotiatedResponse("Application is not ready", healthSchema),
+		},
+	})
+	doc.AddOperation(http.MethodGet, "/api/info", Operation{
+		Summary: "Application name and version",
+		Responses: map[string]Response{
+			"200": jsonResponse("Application name and version", infoSchema),
+		},
+	})
+
+	return doc
+}
+
+// AddOperation registers op for method on path, replacing any existing entry.
+func (d *OpenAPIDocument) AddOperation(method, path string, op Operation) {
+	item, ok := d.Paths[path]
+	if !ok {
+		item = make(PathItem)
+		d.Paths[path] = item
+	}
+	item[strings.ToLower(method)] = op
+}
+
+// OpenAPIJSON serves the OpenAPI document.
+//
+// GET /openapi.json
+//
+// Returns:
+//   - 200: OpenAPI 3 document
+func OpenAPIJSON(doc *OpenAPIDocument) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeMethodNotAllowed(w, r, "GET")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := encodeJSON(w, r, doc); err != nil {
+			// Error encoding response, but status already sent
+			return
+		}
+	}
+}
+
+// jsonResponse builds a Response with an application/json body.
+func jsonResponse(description string, schema Schema) Response {
+	return Response{
+		Description: description,
+		Content:     map[string]MediaType{"application/json": {Schema: schema}},
+	}
+}
+
+// negotiatedResponse builds a Response offering JSON or, via the Accept
+// header, a text/plain status string.
+func negotiatedResponse(description string, schema Schema) Response {
+	response := jsonResponse(description, schema)
+	response.Content["text/plain"] = MediaType{Schema: Schema{Type: "string"}}
+	return response
+}
+
+var healthSchema = Schema{
+	Type: "object",
+	Properties: map[string]Schema{
+		"status":    {Type: "string"},
+		"timestamp": {Type: "string", Format: "date-time"},
+		"version":   {Type: "string"},
+		"checks": {
+			Type: "array",
+			Items: &Schema{
+				Type: "object",
+				Properties: map[string]Schema{
+					"name":   {Type: "string"},
+					"status": {Type: "string"},
+					"error":  {Type: "string"},
+				},
+			},
+		},
+	},
+}
+
+var infoSchema = Schema{
+	Type: "object",
+	Properties: map[string]Schema{
+		"name":       {Type: "string"},
+		"version":    {Type: "string"},
+		"go_version": {Type: "string"},
+		"os":         {Type: "string"},
+		"arch":       {Type: "string"},
+	},
+}
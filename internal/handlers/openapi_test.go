@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/your-org/go-template-project/internal/metrics"
+)
+
+func fetchOpenAPISpec(t *testing.T, router http.Handler) OpenAPIDocument {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	var doc OpenAPIDocument
+	if err := json.Unmarshal(rr.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to unmarshal spec: %v", err)
+	}
+	return doc
+}
+
+func TestOpenAPISpecServed(t *testing.T) {
+	doc := fetchOpenAPISpec(t, NewRouter(RouterOptions{Name: "test-app", Version: "1.0.0"}))
+
+	if doc.OpenAPI == "" {
+		t.Error("Expected openapi version to be set")
+	}
+	if doc.Info.Title != "test-app" || doc.Info.Version != "1.0.0" {
+		t.Errorf("Expected info test-app/1.0.0, got %s/%s", doc.Info.Title, doc.Info.Version)
+	}
+
+	for _, path := range []string{"/health", "/ready", "/api/info"} {
+		op, ok := doc.Paths[path]["get"]
+		if !ok {
+			t.Errorf("Expected GET %s in spec", path)
+			continue
+		}
+		if _, ok := op.Responses["200"]; !ok {
+			t.Errorf("Expected 200 response for GET %s", path)
+		}
+	}
+}
+
+func TestOpenAPISpecIncludesRegisteredRoutes(t *testing.T) {
+	doc := fetchOpenAPISpec(t, NewRouter(RouterOptions{Name: "test-app", Version: "1.0.0", Metrics: metrics.New()}))
+
+	if _, ok := doc.Paths["/metrics.json"]["get"]; !ok {
+		t.Error("Expected GET /metrics.json in spec when metrics are enabled")
+	}
+}
+
+func TestOpenAPIJSONMethodNotAllowed(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/openapi.json", nil)
+	rr := httptest.NewRecorder()
+	OpenAPIJSON(OpenAPISpec("test-app", "1.0.0"))(rr, req)
+
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status %d, got %d", http.StatusMethodNotAllowed, rr.Code)
+	}
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ReadinessFunc reports whether a dependency is ready to serve traffic.
+// A non-nil error marks the dependency as not ready.
+type ReadinessFunc func(ctx context.Context) error
+
+// CheckResult is the outcome of a single readiness check. A failed
+// non-critical check degrades readiness instead of failing it.
+type CheckResult struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Critical bool   `json:"critical"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Check result statuses.
+const (
+	CheckStatusOK      = "ok"
+	CheckStatusFailed  = "failed"
+	CheckStatusTimeout = "timeout"
+)
+
+type namedCheck struct {
+	name     string
+	check    ReadinessFunc
+	critical bool
+}
+
+// ReadinessRegistry holds the readiness checks consulted by ReadinessCheck.
+// Checks run concurrently, but never more than the configured bound at once,
+// so a large number of checks can't overwhelm shared dependencies. An
+// optional timeout caps how long a whole run may take.
+type ReadinessRegistry struct {
+	mu          sync.RWMutex
+	checks      []namedCheck
+	concurrency int
+	timeout     time.Duration
+}
+
+// NewReadinessRegistry creates an empty registry that runs at most
+// concurrency checks at a time. Values below 1 are treated as 1.
+func NewReadinessRegistry(concurrency int) *ReadinessRegistry {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &ReadinessRegistry{concurrency: concurrency}
+}
+
+// Register adds a named critical readiness check. Its failure makes the
+// service not ready.
+func (r *ReadinessRegistry) Register(name string, check ReadinessFunc) {
+	r.add(namedCheck{name: name, check: check, critical: true})
+}
+
+// RegisterNonCritical adds a named readiness check whose failure only marks
+// the service degraded, so probes keep passing.
+func (r *ReadinessRegistry) RegisterNonCritical(name string, check ReadinessFunc) {
+	r.add(namedCheck{name: name, check: check})
+}
+
+// SetTimeout sets the budget shared by every check in a run. Checks still
+// running when it expires are reported as timed out; 0 disables the budget.
+func (r *ReadinessRegistry) SetTimeout(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.timeout = d
+}
+
+func (r *ReadinessRegistry) add(c namedCheck) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, c)
+}
+
+// Run executes every registered check using a bounded worker pool and
+// returns the results in registration order. With a timeout set, Run
+// returns once it expires even if checks ignore their context.
+func (r *ReadinessRegistry) Run(ctx context.Context) []CheckResult {
+	r.mu.RLock()
+	checks := make([]namedCheck, len(r.checks))
+	copy(checks, r.checks)
+	timeout := r.timeout
+	r.mu.RUnlock()
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	results := make([]CheckResult, len(checks))
+	jobs := make(chan int)
+
+	workers := r.concurrency
+	if workers > len(checks) {
+		workers = len(checks)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				results[idx] = runCheckWithin(ctx, checks[idx], timeout)
+			}
+		}()
+	}
+
+	for idx := range checks {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// runCheckWithin runs c but stops waiting once a budgeted ctx is done. A
+// check that hangs keeps its goroutine until it returns on its own.
+func runCheckWithin(ctx context.Context, c namedCheck, budget time.Duration) CheckResult {
+	if budget <= 0 {
+		return runCheck(ctx, c)
+	}
+
+	timedOut := CheckResult{
+		Name:     c.name,
+		Status:   CheckStatusTimeout,
+		Critical: c.critical,
+		Error:    fmt.Sprintf("readiness budget of %s exceeded", budget),
+	}
+	if ctx.Err() != nil {
+		return timedOut
+	}
+
+	done := make(chan CheckResult, 1)
+	go func() { done <- runCheck(ctx, c) }()
+
+	select {
+	case result := <-done:
+		return result
+	case <-ctx.Done():
+		return timedOut
+	}
+}
+
+// runCheck runs c, reporting a panic as a failed check so one broken
+// dependency check can't crash the server or hang Run.
+func runCheck(ctx context.Context, c namedCheck) (result CheckResult) {
+	defer func() {
+		if p := recover(); p != nil {
+			result = CheckResult{
+				Name:     c.name,
+				Status:   CheckStatusFailed,
+				Critical: c.critical,
+				Error:    fmt.Sprintf("check panicked: %v", p),
+			}
+		}
+	}()
+
+	if err := c.check(ctx); err != nil {
+		return CheckResult{Name: c.name, Status: CheckStatusFailed, Critical: c.critical, Error: err.Error()}
+	}
+	return CheckResult{Name: c.name, Status: CheckStatusOK, Critical: c.critical}
+}
+
+// criticalPassed reports whether every critical result succeeded.
+func criticalPassed(results []CheckResult) bool {
+	for _, result := range results {
+		if result.Critical && result.Status != CheckStatusOK {
+			return false
+		}
+	}
+	return true
+}
+
+// allPassed reports whether every result succeeded.
+func allPassed(results []CheckResult) bool {
+	for _, result := range results {
+		if result.Status != CheckStatusOK {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReadinessRegistryBoundsConcurrency(t *testing.T) {
+	const (
+		bound     = 3
+		numChecks = 20
+	)
+
+	registry := NewReadinessRegistry(bound)
+
+	var active, maxActive int32
+	for i := 0; i < numChecks; i++ {
+		registry.Register(fmt.Sprintf("check-%d", i), func(ctx context.Context) error {
+			current := atomic.AddInt32(&active, 1)
+			defer atomic.AddInt32(&active, -1)
+
+			for {
+				seen := atomic.LoadInt32(&maxActive)
+				if current <= seen || atomic.CompareAndSwapInt32(&maxActive, seen, current) {
+					break
+				}
+			}
+
+			time.Sleep(5 * time.Millisecond)
+			return nil
+		})
+	}
+
+	results := registry.Run(context.Background())
+
+	if got := atomic.LoadInt32(&maxActive); got > bound {
+		t.Errorf("Expected at most %d concurrent checks, observed %d", bound, got)
+	}
+
+	if len(results) != numChecks {
+		t.Fatalf("Expected %d results, got %d", numChecks, len(results))
+	}
+
+	for i, result := range results {
+		if want := fmt.Sprintf("check-%d", i); result.Name != want {
+			t.Errorf("Expected result %d to be %s, got %s", i, want, result.Name)
+		}
+		if result.Status != CheckStatusOK {
+			t.Errorf("Expected %s to pass, got %s", result.Name, result.Status)
+		}
+	}
+}
+
+func TestReadinessRegistryRecoversPanickingCheck(t *testing.T) {
+	registry := NewReadinessRegistry(2)
+	registry.Register("broken", func(ctx context.Context) error {
+		panic("nil pool")
+	})
+	registry.RegisterNonCritical("cache", func(ctx context.Context) error { return nil })
+
+	for _, timeout := range []time.Duration{0, time.Second} {
+		registry.SetTimeout(timeout)
+		results := registry.Run(context.Background())
+
+		if len(results) != 2 {
+			t.Fatalf("Expected 2 results, got %d", len(results))
+		}
+		if got := results[0]; got.Status != CheckStatusFailed || got.Error != "check panicked: nil pool" {
+			t.Errorf("Expected the panic reported as a failure with timeout %s, got %+v", timeout, got)
+		}
+		if got := results[1]; got.Status != CheckStatusOK {
+			t.Errorf("Expected the other check to pass with timeout %s, got %+v", timeout, got)
+		}
+	}
+}
+
+func TestReadinessCheckFailingDependency(t *testing.T) {
+	registry := NewReadinessRegistry(2)
+	registry.Register("cache", func(ctx context.Context) error { return nil })
+	registry.Register("database", func(ctx context.Context) error { return errors.New("connection refused") })
+
+	handler := ReadinessCheck(registry)
+
+	req := httptest.NewRequest(http.MethodGet, "/ready", nil)
+	rr := httptest.NewRecorder()
+	handler(rr, req)
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status code %d, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+
+	var response HealthResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if len(response.Checks) != 2 {
+		t.Fatalf("Expected 2 check results, got %d", len(response.Checks))
+	}
+
+	if response.Checks[1].Status != CheckStatusFailed || response.Checks[1].Error != "connection refused" {
+		t.Errorf("Expected database check to fail with its error, got %+v", response.Checks[1])
+	}
+}
+
+func TestReadinessCheckTimeoutBudget(t *testing.T) {
+	const budget = 50 * time.Millisecond
+
+	// The hanging check ignores its context, like a stuck client would
+	release := make(chan struct{})
+	defer close(release)
+
+	registry := NewReadinessRegistry(2)
+	registry.SetTimeout(budget)
+	registry.Register("cache", func(ctx context.Context) error { return nil })
+	registry.Register("billing", func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+
+	start := time.Now()
+	rr := httptest.NewRecorder()
+	ReadinessCheck(registry)(rr, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	elapsed := time.Since(start)
+
+	if elapsed > budget+time.Second {
+		t.Errorf("Expected /ready to return within the %v budget, took %v", budget, elapsed)
+	}
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status code %d, got %d", http.StatusServiceUnavailable, rr.Code)
+	}
+
+	var response HealthResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(response.Checks) != 2 {
+		t.Fatalf("Expected 2 check results, got %d", len(response.Checks))
+	}
+	if response.Checks[0].Status != CheckStatusOK {
+		t.Errorf("Expected cache check to pass, got %+v", response.Checks[0])
+	}
+	if response.Checks[1].Status != CheckStatusTimeout {
+		t.Errorf("Expected billing check to time out, got %+v", response.Checks[1])
+	}
+}
+
+func TestReadinessCheckDegradation(t *testing.T) {
+	ok := func(ctx context.Context) error { return nil }
+	failing := func(ctx context.Context) error { return errors.New("connection refused") }
+
+	tests := []struct {
+		name       string
+		register   func(r *ReadinessRegistry)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "healthy",
+			register: func(r *ReadinessRegistry) {
+				r.Register("database", ok)
+				r.RegisterNonCritical("recommendations", ok)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "ready",
+		},
+		{
+			name: "degraded",
+			register: func(r *ReadinessRegistry) {
+				r.Register("database", ok)
+				r.RegisterNonCritical("recommendations", failing)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "degraded",
+		},
+		{
+			name: "unhealthy",
+			register: func(r *ReadinessRegistry) {
+				r.Register("database", failing)
+				r.RegisterNonCritical("recommendations", failing)
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   "not ready",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewReadinessRegistry(2)
+			tt.register(registry)
+
+			rr := httptest.NewRecorder()
+			ReadinessCheck(registry)(rr, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+			if rr.Code != tt.wantStatus {
+				t.Errorf("Expected status code %d, got %d", tt.wantStatus, rr.Code)
+			}
+
+			var response HealthResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if response.Status != tt.wantBody {
+				t.Errorf("Expected status %q, got %q", tt.wantBody, response.Status)
+			}
+
+			if len(response.Checks) != 2 {
+				t.Fatalf("Expected 2 check results, got %d", len(response.Checks))
+			}
+			if !response.Checks[0].Critical || response.Checks[1].Critical {
+				t.Errorf("Expected only database to be critical, got %+v", response.Checks)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"runtime/debug"
+)
+
+// RecoveryMiddleware turns a panicking handler into a 500 error response and
+// logs the panic with its stack trace, so one bad request can't take down
+// the server. http.ErrAbortHandler is re-panicked, as net/http expects.
+func RecoveryMiddleware(logger *log.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+				if rec == http.ErrAbortHandler {
+					panic(rec)
+				}
+
+				logger.Printf("panic serving %s %s: %v\n%s", r.Method, r.URL.Path, rec, debug.Stack())
+				WriteError(w, r, http.StatusInternalServerError, "Internal server error")
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/your-org/go-template-project/internal/logging"
+)
+
+func TestRequestIDMiddlewareTagsLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	var seen string
+	handler := RequestIDMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestID(r)
+		logging.FromContext(r.Context()).Info("handled")
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	id := rr.Header().Get(RequestIDHeader)
+	if len(id) != 32 {
+		t.Fatalf("Expected a generated 32-character request ID, got %q", id)
+	}
+	if seen != id {
+		t.Errorf("Expected handler to see request ID %q, got %q", id, seen)
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Failed to parse log line %q: %v", buf.String(), err)
+	}
+	if entry["request_id"] != id {
+		t.Errorf("Expected log line request_id %q, got %v", id, entry["request_id"])
+	}
+}
+
+func TestRequestIDMiddlewareReusesClientID(t *testing.T) {
+	handler := RequestIDMiddleware(slog.Default())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	tests := []struct {
+		name   string
+		header string
+		reused bool
+	}{
+		{"valid", "req-42", true},
+		{"control characters", "bad\tid", false},
+		{"too long", strings.Repeat("a", maxRequestIDLength+1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(RequestIDHeader, tt.header)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if got := rr.Header().Get(RequestIDHeader); (got == tt.header) != tt.reused {
+				t.Errorf("Expected reused=%t for %q, got %q", tt.reused, tt.header, got)
+			}
+		})
+	}
+}
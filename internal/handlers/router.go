@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/your-org/go-template-project/internal/metrics"
+	"github.com/your-org/go-template-project/internal/tracing"
+)
+
+// RequiredRoutes are the operational endpoints every server must expose.
+// SelfCheck fails if any of them is missing.
+var RequiredRoutes = []string{"/health", "/ready"}
+
+// RouterOptions holds the dependencies used to build the router.
+type RouterOptions struct {
+	Name    string
+	Version string
+
+	// Commit is the VCS revision reported by /version; empty omits it.
+	Commit string
+
+	// Readiness holds the checks consulted by /ready; nil means always ready.
+	Readiness *ReadinessRegistry
+
+	// Metrics, when set, records request metrics and serves them at /metrics
+	// (Prometheus) and /metrics.json.
+	Metrics *metrics.Collector
+
+	// DisableInfo leaves /api/info unregistered so it returns 404. Health
+	// and readiness endpoints can't be disabled.
+	DisableInfo bool
+
+	// Maintenance, with AdminAPIKey set, is toggled via /admin/maintenance.
+	// Register Maintenance.Check on Readiness for it to affect /ready.
+	Maintenance *Maintenance
+	AdminAPIKey string
+
+	// Routes, when set, registers application routes on the router's mux so
+	// they are served behind the same middleware stack.
+	Routes func(mux *http.ServeMux)
+
+	// AccessLogMode selects which requests are logged (see AccessLogMiddleware).
+	// Empty means no access logging.
+	AccessLogMode string
+
+	// HandlerTimeout bounds how long a handler may run before the request
+	// fails with 503 (see TimeoutMiddleware); zero disables it.
+	HandlerTimeout time.Duration
+
+	// TrustProxy takes the client IP from X-Forwarded-For/X-Real-IP when the
+	// request comes from a trusted proxy (see ClientIPMiddleware).
+	TrustProxy bool
+
+	// ForceHTTPS redirects plain-HTTP requests to HTTPS, except for the
+	// RequiredRoutes health checks (see HTTPSRedirectMiddleware).
+	ForceHTTPS bool
+
+	// SecurityHeaders are set on every response (see
+	// SecurityHeadersMiddleware); nil sets none. Start from
+	// DefaultSecurityHeaders for a sane set.
+	SecurityHeaders map[string]string
+
+	// CORSAllowedOrigins, when set, allows cross-origin requests from these
+	// origins (see CORSMiddleware); nil allows none.
+	CORSAllowedOrigins []string
+
+	// Tracer, when set, records a span per request (see TracingMiddleware).
+	Tracer *tracing.Tracer
+
+	// PrettyJSON indents every JSON response, including WriteError
+	// envelopes, which is easier to read when debugging with curl.
+	PrettyJSON bool
+
+	// BasePath, when set, mounts every route under it so /health is served
+	// at BasePath+"/health" and the bare paths return 404. Leading and
+	// trailing slashes are normalized; empty or "/" serves from the root.
+	BasePath string
+}
+
+// Router is the handler built by NewRouter. It serves requests through the
+// full middleware stack and keeps the inner mux for SelfCheck.
+type Router struct {
+	http.Handler
+	mux *http.ServeMux
+}
+
+// NewRouter registers all application routes on a new handler.
+func NewRouter(opts RouterOptions) *Router {
+	mux := http.NewServeMux()
+
+	// Health endpoints
+	mux.HandleFunc("/health", HealthCheck(opts.Version))
+	mux.HandleFunc("/ready", ReadinessCheck(opts.Readiness))
+
+	// API contract; routes added below register their operations too
+	spec := OpenAPISpec(opts.Name, opts.Version)
+
+	// Example API endpoint
+	if opts.DisableInfo {
+		delete(spec.Paths, "/api/info")
+	} else {
+		mux.HandleFunc("/api/info", Info(opts.Name, opts.Version))
+	}
+
+	mux.HandleFunc("/version", Version(opts.Version, opts.Commit))
+	spec.AddOperation(http.MethodGet, "/version", Operation{
+		Summary: "Version and commit",
+		Responses: map[string]Response{
+			"200": negotiatedResponse("Version and commit", Schema{
+				Type: "object",
+				Properties: map[string]Schema{
+					"version": {Type: "string"},
+					"commit":  {Type: "string"},
+				},
+			}),
+		},
+	})
+
+	if opts.Metrics != nil {
+		mux.HandleFunc("/metrics", Prometheus(opts.Metrics))
+		spec.AddOperation(http.MethodGet, "/metrics", Operation{
+			Summary: "Prometheus metrics",
+			Responses: map[string]Response{
+				"200": {
+					Description: "Metrics in the Prometheus text format",
+					Content:     map[string]MediaType{"text/plain": {Schema: Schema{Type: "string"}}},
+				},
+			},
+		})
+
+		mux.HandleFunc("/metrics.json", MetricsJSON(opts.Metrics))
+		spec.AddOperation(http.MethodGet, "/metrics.json", Operation{
+			Summary: "Request and runtime metrics",
+			Responses: map[string]Response{
+				"200": jsonResponse("Metrics snapshot", Schema{Type: "object"}),
+			},
+		})
+	}
+
+	// Admin endpoints are only exposed when an API key guards them
+	if opts.Maintenance != nil && opts.AdminAPIKey != "" {
+		mux.HandleFunc("/admin/maintenance", MaintenanceToggle(opts.Maintenance, opts.AdminAPIKey))
+	}
+
+	if opts.Routes != nil {
+		opts.Routes(mux)
+	}
+
+	mux.HandleFunc("/openapi.json", OpenAPIJSON(spec))
+
+	// The timeout sits inside StripPrefix so TimeoutExemptPaths match the
+	// routes as registered, with or without a base path
+	var handler http.Handler = mux
+	if opts.HandlerTimeout > 0 {
+		handler = TimeoutMiddleware(opts.HandlerTimeout)(handler)
+	}
+	if base := CleanBasePath(opts.BasePath); base != "" {
+		root := http.NewServeMux()
+		root.Handle(base+"/", http.StripPrefix(base, handler))
+		handler = root
+	}
+	handler = RecoveryMiddleware(log.Default())(handler)
+	if opts.Metrics != nil {
+		handler = MetricsMiddleware(opts.Metrics)(handler)
+	}
+	if opts.AccessLogMode != "" {
+		handler = AccessLogMiddleware(opts.AccessLogMode, log.Default())(handler)
+	}
+	if opts.Tracer != nil {
+		handler = TracingMiddleware(opts.Tracer)(handler)
+	}
+	if len(opts.CORSAllowedOrigins) > 0 {
+		handler = CORSMiddleware(opts.CORSAllowedOrigins)(handler)
+	}
+	if opts.SecurityHeaders != nil {
+		handler = SecurityHeadersMiddleware(opts.SecurityHeaders, opts.TrustProxy)(handler)
+	}
+	if opts.ForceHTTPS {
+		exempt := make([]string, len(RequiredRoutes))
+		for i, path := range RequiredRoutes {
+			exempt[i] = CleanBasePath(opts.BasePath) + path
+		}
+		handler = HTTPSRedirectMiddleware(opts.TrustProxy, exempt...)(handler)
+	}
+	handler = ClientIPMiddleware(opts.TrustProxy)(handler)
+	handler = RequestIDMiddleware(slog.Default())(handler)
+	if opts.PrettyJSON {
+		handler = prettyJSONMiddleware(handler)
+	}
+
+	return &Router{Handler: handler, mux: mux}
+}
+
+// SelfCheck verifies that every route in RequiredRoutes is registered and
+// reachable by issuing an in-process GET against each on the router's mux.
+// A 404 means the route was never registered; any other status means a
+// handler answered. The probes bypass the middleware, so they don't show up
+// in access logs, metrics or traces, and the base path is mounted outside
+// the mux, so it needs no special handling.
+//
+// Run it after wiring the router so refactors that drop a health check fail
+// at startup instead of in production.
+func (r *Router) SelfCheck() error {
+	var missing []string
+	for _, path := range RequiredRoutes {
+		rr := httptest.NewRecorder()
+		r.mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+
+		if rr.Code == http.StatusNotFound {
+			missing = append(missing, path)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("required routes not registered: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// CleanBasePath normalizes a route prefix to a leading slash and no
+// trailing slash, so "myservice/" becomes "/myservice". Empty and "/"
+// return "".
+func CleanBasePath(base string) string {
+	base = strings.Trim(base, "/")
+	if base == "" {
+		return ""
+	}
+	return "/" + base
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/your-org/go-template-project/internal/metrics"
+)
+
+func TestNewRouterRoutes(t *testing.T) {
+	router := NewRouter(RouterOptions{Name: "test-app", Version: "1.0.0"})
+
+	for _, path := range []string{"/health", "/ready", "/api/info"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("Expected status %d for %s, got %d", http.StatusOK, path, rr.Code)
+		}
+	}
+}
+
+func TestNewRouterDisabledEndpoints(t *testing.T) {
+	router := NewRouter(RouterOptions{
+		Name:        "test-app",
+		Version:     "1.0.0",
+		DisableInfo: true,
+	})
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/health", http.StatusOK},
+		{"/ready", http.StatusOK},
+		{"/version", http.StatusOK},
+		{"/api/info", http.StatusNotFound},
+		// No metrics collector, so metrics are off too
+		{"/metrics", http.StatusNotFound},
+		{"/metrics.json", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		if rr.Code != tt.status {
+			t.Errorf("Expected status %d for %s, got %d", tt.status, tt.path, rr.Code)
+		}
+	}
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if strings.Contains(rr.Body.String(), "/api/info") {
+		t.Error("Expected disabled /api/info to be left out of the OpenAPI spec")
+	}
+}
+
+func TestSelfCheckPasses(t *testing.T) {
+	if err := NewRouter(RouterOptions{Name: "test-app", Version: "1.0.0"}).SelfCheck(); err != nil {
+		t.Errorf("SelfCheck() returned error: %v", err)
+	}
+}
+
+func TestSelfCheckBypassesMiddleware(t *testing.T) {
+	collector := metrics.New()
+	router := NewRouter(RouterOptions{
+		Name:     "test-app",
+		Version:  "1.0.0",
+		Metrics:  collector,
+		BasePath: "/svc",
+	})
+
+	if err := router.SelfCheck(); err != nil {
+		t.Fatalf("SelfCheck() returned error: %v", err)
+	}
+	if total := collector.Snapshot().TotalRequests; total != 0 {
+		t.Errorf("Expected SelfCheck not to record metrics, got %d requests", total)
+	}
+}
+
+func TestSelfCheckMissingHealth(t *testing.T) {
+	// Router with health registration skipped
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ready", ReadinessCheck(nil))
+
+	err := (&Router{Handler: mux, mux: mux}).SelfCheck()
+	if err == nil {
+		t.Fatal("Expected SelfCheck to fail when /health is not registered")
+	}
+
+	if !strings.Contains(err.Error(), "/health") {
+		t.Errorf("Expected error to name /health, got: %v", err)
+	}
+}
+
+func TestNewRouterBasePath(t *testing.T) {
+	for _, base := range []string{"/myservice", "/myservice/", "myservice"} {
+		t.Run(base, func(t *testing.T) {
+			router := NewRouter(RouterOptions{Name: "test-app", Version: "1.0.0", BasePath: base})
+
+			tests := []struct {
+				path   string
+				status int
+			}{
+				{"/myservice/health", http.StatusOK},
+				{"/myservice/ready", http.StatusOK},
+				{"/myservice/api/info", http.StatusOK},
+				{"/health", http.StatusNotFound},
+				{"/api/info", http.StatusNotFound},
+			}
+
+			for _, tt := range tests {
+				rr := httptest.NewRecorder()
+				router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+				if rr.Code != tt.status {
+					t.Errorf("GET %s: expected status %d, got %d", tt.path, tt.status, rr.Code)
+				}
+			}
+
+			if err := router.SelfCheck(); err != nil {
+				t.Errorf("SelfCheck() returned error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCleanBasePath(t *testing.T) {
+	tests := map[string]string{
+		"":            "",
+		"/":           "",
+		"/myservice":  "/myservice",
+		"/myservice/": "/myservice",
+		"myservice":   "/myservice",
+		"/a/b//":      "/a/b",
+	}
+
+	for input, want := range tests {
+		if got := CleanBasePath(input); got != want {
+			t.Errorf("CleanBasePath(%q): expected %q, got %q", input, want, got)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"maps"
+	"net/http"
+)
+
+// HSTSHeader is the Strict-Transport-Security header name.
+const HSTSHeader = "Strict-Transport-Security"
+
+// DefaultSecurityHeaders returns the headers SecurityHeadersMiddleware sets
+// unless overridden. The map is a fresh copy the caller may modify.
+func DefaultSecurityHeaders() map[string]string {
+	return map[string]string{
+		"X-Content-Type-Options": "nosniff",
+		"X-Frame-Options":        "DENY",
+		"Referrer-Policy":        "strict-origin-when-cross-origin",
+		HSTSHeader:               "max-age=31536000; includeSubDomains",
+	}
+}
+
+// SecurityHeadersMiddleware sets headers on every response before the
+// handler runs, so a handler can still replace them. An empty value leaves
+// that header unset. Strict-Transport-Security is only sent on requests
+// that arrived over HTTPS (see HTTPSRedirectMiddleware for how trustProxy
+// applies), since browsers ignore it over plain HTTP.
+func SecurityHeadersMiddleware(headers map[string]string, trustProxy bool) func(http.Handler) http.Handler {
+	headers = maps.Clone(headers)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h := w.Header()
+			for name, value := range headers {
+				if value == "" {
+					continue
+				}
+				if http.CanonicalHeaderKey(name) == HSTSHeader && !isHTTPS(r, trustProxy) {
+					continue
+				}
+				h.Set(name, value)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSecurityHeadersMiddlewareDefaults(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := SecurityHeadersMiddleware(DefaultSecurityHeaders(), false)(next)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/api/info", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	for name, want := range map[string]string{
+		"X-Content-Type-Options": "nosniff",
+		"X-Frame-Options":        "DENY",
+		"Referrer-Policy":        "strict-origin-when-cross-origin",
+	} {
+		if got := rr.Header().Get(name); got != want {
+			t.Errorf("Expected %s %q, got %q", name, want, got)
+		}
+	}
+	if got := rr.Header().Get(HSTSHeader); got != "" {
+		t.Errorf("Expected no %s over plain HTTP, got %q", HSTSHeader, got)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "https://example.com/api/info", nil)
+	req.TLS = &tls.ConnectionState{}
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if got := rr.Header().Get(HSTSHeader); got == "" {
+		t.Errorf("Expected %s over HTTPS", HSTSHeader)
+	}
+}
+
+func TestSecurityHeadersMiddlewareProxiedHTTPS(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	handler := SecurityHeadersMiddleware(DefaultSecurityHeaders(), true)(next)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/info", nil)
+	req.RemoteAddr = "10.0.0.2:1234"
+	req.Header.Set("X-Forwarded-Proto", "https")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if got := rr.Header().Get(HSTSHeader); got == "" {
+		t.Errorf("Expected %s behind a TLS-terminating proxy", HSTSHeader)
+	}
+}
+
+func TestSecurityHeadersMiddlewareOverrides(t *testing.T) {
+	headers := DefaultSecurityHeaders()
+	headers["X-Frame-Options"] = "SAMEORIGIN"
+	headers["X-Content-Type-Options"] = ""
+	headers["Content-Security-Policy"] = "default-src 'self'"
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Referrer-Policy", "no-referrer")
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rr := httptest.NewRecorder()
+	SecurityHeadersMiddleware(headers, false)(next).ServeHTTP(rr, req)
+
+	for name, want := range map[string]string{
+		"X-Frame-Options":         "SAMEORIGIN",
+		"X-Content-Type-Options":  "",
+		"Content-Security-Policy": "default-src 'self'",
+		"Referrer-Policy":         "no-referrer",
+	} {
+		if got := rr.Header().Get(name); got != want {
+			t.Errorf("Expected %s %q, got %q", name, want, got)
+		}
+	}
+}
+
+func TestRouterSecurityHeaders(t *testing.T) {
+	headersFor := func(opts RouterOptions) http.Header {
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		rr := httptest.NewRecorder()
+		NewRouter(opts).ServeHTTP(rr, req)
+		return rr.Header()
+	}
+
+	if got := headersFor(RouterOptions{Name: "test-app", Version: "1.0.0"}).Get("X-Frame-Options"); got != "" {
+		t.Errorf("Expected no security headers without SecurityHeaders, got X-Frame-Options %q", got)
+	}
+
+	headers := headersFor(RouterOptions{Name: "test-app", Version: "1.0.0", SecurityHeaders: DefaultSecurityHeaders()})
+	if got := headers.Get("X-Frame-Options"); got != "DENY" {
+		t.Errorf("Expected X-Frame-Options DENY, got %q", got)
+	}
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// TimeoutExemptPaths are served without TimeoutMiddleware's deadline. A path
+// matches exactly or as a prefix followed by "/". Streaming endpoints belong
+// here, since http.TimeoutHandler buffers the response and cannot flush.
+var TimeoutExemptPaths = []string{"/metrics"}
+
+// timeoutMessage is the error message of a timed-out request.
+const timeoutMessage = "Request timed out"
+
+// TimeoutMiddleware fails requests whose handler runs longer than d with a
+// 503 Service Unavailable WriteError envelope, using http.TimeoutHandler.
+// The handler's context is cancelled at the deadline so it can stop work
+// early. A d of zero or less disables the timeout.
+func TimeoutMiddleware(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if d <= 0 {
+			return next
+		}
+
+		timeout := http.TimeoutHandler(next, d, timeoutMessage)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if timeoutExempt(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			timeout.ServeHTTP(&timeoutErrorWriter{ResponseWriter: w, r: r}, r)
+		})
+	}
+}
+
+// timeoutErrorWriter replaces http.TimeoutHandler's plain-text timeout
+// response with a WriteError envelope. TimeoutHandler writes a 503 status
+// and then its message, so a 503 is held back until the first write shows
+// whether it is the timeout or a handler's own response.
+type timeoutErrorWriter struct {
+	http.ResponseWriter
+	r       *http.Request
+	pending bool
+}
+
+func (w *timeoutErrorWriter) WriteHeader(status int) {
+	if status == http.StatusServiceUnavailable {
+		w.pending = true
+		return
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *timeoutErrorWriter) Write(b []byte) (int, error) {
+	if w.pending {
+		w.pending = false
+		if string(b) == timeoutMessage {
+			WriteError(w.ResponseWriter, w.r, http.StatusServiceUnavailable, timeoutMessage)
+			return len(b), nil
+		}
+		w.ResponseWriter.WriteHeader(http.StatusServiceUnavailable)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the underlying writer for http.ResponseController.
+func (w *timeoutErrorWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func timeoutExempt(path string) bool {
+	for _, exempt := range TimeoutExemptPaths {
+		if path == exempt || strings.HasPrefix(path, exempt+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTimeoutMiddleware(t *testing.T) {
+	handler := TimeoutMiddleware(50 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("slow") != "" {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(time.Second):
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{"fast handler succeeds", "/api/info", http.StatusOK},
+		{"slow handler times out", "/api/info?slow=1", http.StatusServiceUnavailable},
+		{"exempt path is not timed", "/metrics?slow=1", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rr.Code != tt.want {
+				t.Errorf("Expected status %d, got %d", tt.want, rr.Code)
+			}
+			if tt.want != http.StatusServiceUnavailable {
+				return
+			}
+
+			if ct := rr.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Expected Content-Type application/json, got %q", ct)
+			}
+			var body ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Expected a JSON error envelope, got %q: %v", rr.Body.String(), err)
+			}
+			if body.Error.Code != http.StatusServiceUnavailable || body.Error.Message != timeoutMessage {
+				t.Errorf("Expected a 503 %q envelope, got %+v", timeoutMessage, body.Error)
+			}
+		})
+	}
+}
+
+func TestTimeoutMiddlewareKeepsHandler503(t *testing.T) {
+	handler := TimeoutMiddleware(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("draining"))
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/info", nil))
+
+	if rr.Code != http.StatusServiceUnavailable || rr.Body.String() != "draining" {
+		t.Errorf("Expected the handler's own 503 to pass through, got %d %q", rr.Code, rr.Body.String())
+	}
+}
+
+func TestTimeoutMiddlewareDisabled(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Deadline(); ok {
+			t.Error("Expected no deadline when the timeout is disabled")
+		}
+	})
+
+	rr := httptest.NewRecorder()
+	TimeoutMiddleware(0)(next).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", rr.Code)
+	}
+}
+
+func TestRouterTimeoutExemptPathsWithBasePath(t *testing.T) {
+	slow := func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}
+	router := NewRouter(RouterOptions{
+		Name:           "test-app",
+		Version:        "1.0.0",
+		HandlerTimeout: 20 * time.Millisecond,
+		BasePath:       "/svc",
+		Routes: func(mux *http.ServeMux) {
+			mux.HandleFunc("/slow", slow)
+			mux.HandleFunc("/metrics/stream", slow)
+		},
+	})
+
+	for path, want := range map[string]int{
+		"/svc/slow":           http.StatusServiceUnavailable,
+		"/svc/metrics/stream": http.StatusOK,
+	} {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		if rr.Code != want {
+			t.Errorf("Expected %s status %d, got %d", path, want, rr.Code)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/your-org/go-template-project/internal/tracing"
+)
+
+// TracingMiddleware starts a span per request, continuing any trace passed
+// in the incoming traceparent header, and records the method, path, client
+// address and response status as attributes. A nil tracer disables tracing.
+func TracingMiddleware(tracer *tracing.Tracer) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if tracer == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			if sc, ok := tracing.Extract(r.Header); ok {
+				ctx = tracing.ContextWithRemoteSpanContext(ctx, sc)
+			}
+
+			ctx, span := tracer.Start(ctx, r.Method+" "+r.URL.Path)
+			defer span.End()
+
+			span.SetAttribute("http.request.method", r.Method)
+			span.SetAttribute("url.path", r.URL.Path)
+			span.SetAttribute("client.address", ClientIP(r))
+
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r.WithContext(ctx))
+
+			span.SetAttribute("http.response.status_code", rec.status)
+			span.Error = rec.status >= http.StatusInternalServerError
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/your-org/go-template-project/internal/tracing"
+)
+
+func TestTracingMiddlewareRecordsSpanPerRequest(t *testing.T) {
+	recorder := &tracing.Recorder{}
+	handler := TracingMiddleware(tracing.New(recorder))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, path := range []string{"/health", "/missing"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	spans := recorder.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(spans))
+	}
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/health", http.StatusOK},
+		{"/missing", http.StatusNotFound},
+	}
+	for i, tt := range tests {
+		span := spans[i]
+		if span.Attributes["http.request.method"] != http.MethodGet {
+			t.Errorf("Expected method GET, got %v", span.Attributes["http.request.method"])
+		}
+		if span.Attributes["url.path"] != tt.path {
+			t.Errorf("Expected path %s, got %v", tt.path, span.Attributes["url.path"])
+		}
+		if span.Attributes["http.response.status_code"] != tt.status {
+			t.Errorf("Expected status %d, got %v", tt.status, span.Attributes["http.response.status_code"])
+		}
+	}
+}
+
+func TestTracingMiddlewarePropagatesTraceContext(t *testing.T) {
+	recorder := &tracing.Recorder{}
+
+	var inner tracing.SpanContext
+	handler := TracingMiddleware(tracing.New(recorder))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inner = tracing.SpanContextFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/info", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Spans()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+
+	span := spans[0]
+	if got := span.Context.TraceID.String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Expected trace ID from header, got %s", got)
+	}
+	if got := span.Parent.String(); got != "00f067aa0ba902b7" {
+		t.Errorf("Expected parent span ID from header, got %s", got)
+	}
+	if inner != span.Context {
+		t.Errorf("Expected handler context to carry the request span, got %+v", inner)
+	}
+}
+
+func TestTracingMiddlewareNilTracerIsNoop(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	handler := TracingMiddleware(nil)(next)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", rr.Code)
+	}
+}
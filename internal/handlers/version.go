@@ -0,0 +1,47 @@
+package handlers
+
+import "net/http"
+
+// VersionResponse represents the version response.
+type VersionResponse struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit,omitempty"`
+}
+
+// Version returns just the version and commit, as JSON or, when the client's
+// Accept header prefers text/plain, as "<version> (<commit>)".
+//
+// GET /version
+//
+// Returns:
+//   - 200: Version and commit
+func Version(version, commit string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeMethodNotAllowed(w, r, "GET")
+			return
+		}
+
+		if wantsPlainText(r) {
+			body := version
+			if commit != "" {
+				body += " (" + commit + ")"
+			}
+			writePlainText(w, http.StatusOK, body)
+			return
+		}
+
+		response := VersionResponse{
+			Version: version,
+			Commit:  commit,
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := encodeJSON(w, r, response); err != nil {
+			// Error encoding response, but status already sent
+			return
+		}
+	}
+}
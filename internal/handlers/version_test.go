@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestVersionJSON(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	rr := httptest.NewRecorder()
+	Version("1.2.3", "abc123")(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, rr.Code)
+	}
+	if contentType := rr.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("Expected Content-Type 'application/json', got '%s'", contentType)
+	}
+
+	var response VersionResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Version != "1.2.3" {
+		t.Errorf("Expected version '1.2.3', got '%s'", response.Version)
+	}
+	if response.Commit != "abc123" {
+		t.Errorf("Expected commit 'abc123', got '%s'", response.Commit)
+	}
+}
+
+func TestVersionPlainText(t *testing.T) {
+	tests := []struct {
+		commit string
+		want   string
+	}{
+		{"abc123", "1.2.3 (abc123)"},
+		{"", "1.2.3"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/version", nil)
+		req.Header.Set("Accept", "text/plain")
+		rr := httptest.NewRecorder()
+		Version("1.2.3", tt.commit)(rr, req)
+
+		if contentType := rr.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/plain") {
+			t.Errorf("Expected text/plain Content-Type, got '%s'", contentType)
+		}
+		if body := strings.TrimSpace(rr.Body.String()); body != tt.want {
+			t.Errorf("Expected body '%s', got '%s'", tt.want, body)
+		}
+	}
+}
+
+func TestVersionRegisteredOnRouter(t *testing.T) {
+	router := NewRouter(RouterOptions{Name: "test-app", Version: "1.0.0", Commit: "abc123"})
+
+	req := httptest.NewRequest(http.MethodGet, "/version", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected status code %d, got %d", http.StatusOK, rr.Code)
+	}
+}
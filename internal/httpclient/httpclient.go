@@ -0,0 +1,87 @@
+// Package httpclient provides an HTTP client that retries transient
+// failures, shared by health probes and dependency checks.
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Defaults used by New.
+const (
+	DefaultAttempts = 3
+	DefaultBackoff  = 100 * time.Millisecond
+)
+
+// Client wraps an *http.Client with bounded retries. Transport errors and
+// 5xx responses are retried; other responses are returned as-is.
+type Client struct {
+	// HTTP performs each attempt.
+	HTTP *http.Client
+
+	// Attempts is the total number of tries, including the first.
+	Attempts int
+
+	// Backoff is the wait before the first retry; it doubles after each.
+	Backoff time.Duration
+}
+
+// New returns a Client whose attempts each time out after timeout, using
+// DefaultAttempts and DefaultBackoff.
+func New(timeout time.Duration) *Client {
+	return &Client{
+		HTTP:     &http.Client{Timeout: timeout},
+		Attempts: DefaultAttempts,
+		Backoff:  DefaultBackoff,
+	}
+}
+
+// Get issues a GET to url, retrying transient failures until an attempt
+// succeeds, attempts run out, or ctx is done. When every attempt gets a 5xx,
+// the last response is returned so callers can report its status.
+func (c *Client) Get(ctx context.Context, url string) (*http.Response, error) {
+	attempts := c.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	backoff := c.Backoff
+
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.HTTP.Do(req)
+		switch {
+		case err == nil && resp.StatusCode < http.StatusInternalServerError:
+			return resp, nil
+		case err == nil && attempt == attempts:
+			return resp, nil
+		case err == nil:
+			// Drain so the connection can be reused for the retry
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4<<10))
+			resp.Body.Close()
+			lastErr = fmt.Errorf("status %s", resp.Status)
+		default:
+			lastErr = err
+		}
+
+		if attempt == attempts {
+			return nil, fmt.Errorf("after %d attempts: %w", attempts, lastErr)
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("gave up after %d attempts: %w (last error: %v)", attempt, ctx.Err(), lastErr)
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,118 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer fails the first failures requests with 503, then answers 200.
+func flakyServer(t *testing.T, failures int32) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func testClient() *Client {
+	c := New(time.Second)
+	c.Backoff = time.Millisecond
+	return c
+}
+
+func TestGetRecoversOnRetry(t *testing.T) {
+	srv, calls := flakyServer(t, 1)
+
+	resp, err := testClient().Get(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("Get() returned error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("Expected 2 attempts, got %d", n)
+	}
+}
+
+func TestGetReturnsLastServerError(t *testing.T) {
+	srv, calls := flakyServer(t, 10)
+
+	resp, err := testClient().Get(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("Get() returned error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected final status %d, got %d", http.StatusServiceUnavailable, resp.StatusCode)
+	}
+	if n := calls.Load(); n != DefaultAttempts {
+		t.Errorf("Expected %d attempts, got %d", DefaultAttempts, n)
+	}
+}
+
+func TestGetDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	resp, err := testClient().Get(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("Get() returned error: %v", err)
+	}
+	resp.Body.Close()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected 1 attempt for a 404, got %d", n)
+	}
+}
+
+func TestGetStopsWhenContextDone(t *testing.T) {
+	srv, calls := flakyServer(t, 10)
+
+	c := testClient()
+	c.Backoff = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.Get(ctx, srv.URL)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected Get to return promptly, took %v", elapsed)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected 1 attempt before giving up, got %d", n)
+	}
+}
+
+func TestGetTransportError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	if _, err := testClient().Get(context.Background(), url); err == nil {
+		t.Error("Expected error for unreachable server")
+	}
+}
@@ -0,0 +1,46 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+)
+
+// level is the minimum level of the default logger installed by Install.
+// SetLevel changes it at runtime, e.g. when LOG_LEVEL is hot-reloaded.
+var level slog.LevelVar
+
+// Install makes a text logger writing to w the slog default, logging at
+// the named level (debug, info, warn or error) and above. Output from the
+// log package keeps its destination and format.
+func Install(w io.Writer, name string) error {
+	if err := SetLevel(name); err != nil {
+		return err
+	}
+
+	out, flags, prefix := log.Writer(), log.Flags(), log.Prefix()
+	slog.SetDefault(slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: &level})))
+
+	// SetDefault routes the log package through the new handler; keep it
+	// as it was so existing console output is unchanged
+	log.SetOutput(out)
+	log.SetFlags(flags)
+	log.SetPrefix(prefix)
+	return nil
+}
+
+// SetLevel sets the default logger's minimum level by name.
+func SetLevel(name string) error {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(name)); err != nil {
+		return fmt.Errorf("invalid log level %q: %w", name, err)
+	}
+	level.Set(l)
+	return nil
+}
+
+// Level returns the default logger's minimum level.
+func Level() slog.Level {
+	return level.Level()
+}
@@ -0,0 +1,55 @@
+package logging
+
+import (
+	"bytes"
+	"log"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestInstallFiltersByLevel(t *testing.T) {
+	orig := slog.Default()
+	t.Cleanup(func() {
+		slog.SetDefault(orig)
+		level.Set(slog.LevelInfo)
+	})
+
+	logOut := log.Writer()
+	var buf bytes.Buffer
+	if err := Install(&buf, "info"); err != nil {
+		t.Fatalf("Install() returned error: %v", err)
+	}
+
+	slog.Debug("hidden")
+	slog.Info("shown")
+	if strings.Contains(buf.String(), "hidden") || !strings.Contains(buf.String(), "shown") {
+		t.Errorf("Expected only the info record at level info, got %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := SetLevel("debug"); err != nil {
+		t.Fatalf("SetLevel() returned error: %v", err)
+	}
+	slog.Debug("now visible")
+	if !strings.Contains(buf.String(), "now visible") {
+		t.Errorf("Expected the debug record after SetLevel(debug), got %q", buf.String())
+	}
+	if Level() != slog.LevelDebug {
+		t.Errorf("Expected level %s, got %s", slog.LevelDebug, Level())
+	}
+
+	if log.Writer() != logOut {
+		t.Error("Expected the log package output to be left unchanged")
+	}
+}
+
+func TestSetLevelRejectsUnknownName(t *testing.T) {
+	before := Level()
+	if err := SetLevel("verbose"); err == nil {
+		t.Error("Expected error for an unknown level")
+	}
+	if Level() != before {
+		t.Errorf("Expected level to stay %s, got %s", before, Level())
+	}
+}
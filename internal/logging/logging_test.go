@@ -0,0 +1,27 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil)).With("request_id", "abc123")
+
+	ctx := NewContext(context.Background(), logger)
+	FromContext(ctx).Info("handled")
+
+	if !strings.Contains(buf.String(), "request_id=abc123") {
+		t.Errorf("Expected log line to carry the request ID, got %q", buf.String())
+	}
+}
+
+func TestFromContextFallsBackToDefault(t *testing.T) {
+	if got := FromContext(context.Background()); got != slog.Default() {
+		t.Error("Expected slog.Default() when the context has no logger")
+	}
+}
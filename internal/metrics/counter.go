@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"sync/atomic"
+)
+
+// counterNamePattern is the Prometheus metric name syntax.
+var counterNamePattern = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// Counter is an application-defined metric that only goes up, such as tasks
+// processed. It is safe for concurrent use.
+type Counter struct {
+	name  string
+	help  string
+	value atomic.Uint64
+}
+
+// Inc adds one to the counter.
+func (c *Counter) Inc() {
+	c.value.Add(1)
+}
+
+// Add adds n to the counter.
+func (c *Counter) Add(n uint64) {
+	c.value.Add(n)
+}
+
+// Value returns the current count.
+func (c *Counter) Value() uint64 {
+	return c.value.Load()
+}
+
+// NewCounter registers a counter reported alongside the request metrics on
+// /metrics and /metrics.json. Registering a name again returns the existing
+// counter, so independent packages can share one. It panics if name isn't a
+// valid Prometheus metric name or is already a gauge, as counters are
+// defined by code, not input.
+func (c *Collector) NewCounter(name, help string) *Counter {
+	if !counterNamePattern.MatchString(name) {
+		panic(fmt.Sprintf("metrics: invalid counter name %q", name))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if counter, ok := c.counters[name]; ok {
+		return counter
+	}
+	if _, ok := c.gauges[name]; ok {
+		panic(fmt.Sprintf("metrics: counter name %q is already a gauge", name))
+	}
+	counter := &Counter{name: name, help: help}
+	c.counters[name] = counter
+	return counter
+}
+
+// sortedCounters returns the registered counters ordered by name. c.mu must
+// be held.
+func (c *Collector) sortedCounters() []*Counter {
+	counters := make([]*Counter, 0, len(c.counters))
+	for _, counter := range c.counters {
+		counters = append(counters, counter)
+	}
+	sort.Slice(counters, func(i, j int) bool { return counters[i].name < counters[j].name })
+	return counters
+}
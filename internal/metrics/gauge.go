@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+)
+
+// gauge is an application-defined metric whose value is read on demand,
+// such as a queue's current length.
+type gauge struct {
+	name  string
+	help  string
+	value func() float64
+}
+
+// NewGaugeFunc registers a gauge reported alongside the counters on /metrics
+// and /metrics.json, whose value is read by calling fn at collection time.
+// fn must be safe for concurrent use. Registering a name again replaces its
+// function. It panics if name isn't a valid Prometheus metric name or is
+// already a counter, as gauges are defined by code, not input.
+func (c *Collector) NewGaugeFunc(name, help string, fn func() float64) {
+	if !counterNamePattern.MatchString(name) {
+		panic(fmt.Sprintf("metrics: invalid gauge name %q", name))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.counters[name]; ok {
+		panic(fmt.Sprintf("metrics: gauge name %q is already a counter", name))
+	}
+	c.gauges[name] = &gauge{name: name, help: help, value: fn}
+}
+
+// sortedGauges returns the registered gauges ordered by name. c.mu must be
+// held.
+func (c *Collector) sortedGauges() []*gauge {
+	gauges := make([]*gauge, 0, len(c.gauges))
+	for _, g := range c.gauges {
+		gauges = append(gauges, g)
+	}
+	sort.Slice(gauges, func(i, j int) bool { return gauges[i].name < gauges[j].name })
+	return gauges
+}
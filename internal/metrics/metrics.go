@@ -0,0 +1,235 @@
+// Package metrics collects in-process request and runtime metrics.
+package metrics
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are the latency histogram upper bounds in seconds, matching
+// the Prometheus client defaults.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Collector accumulates per-route request metrics and the counters and
+// gauges registered with NewCounter and NewGaugeFunc. It is safe for
+// concurrent use.
+type Collector struct {
+	mu       sync.Mutex
+	start    time.Time
+	buckets  []float64
+	requests map[routeKey]*routeStats
+	counters map[string]*Counter
+	gauges   map[string]*gauge
+}
+
+type routeKey struct {
+	method string
+	path   string
+}
+
+type routeStats struct {
+	count         int64
+	errors        int64
+	totalDuration time.Duration
+	maxDuration   time.Duration
+	bucketCounts  []int64 // requests per bucket, not cumulative; +Inf is implied by count
+}
+
+// New creates an empty collector using DefaultBuckets.
+func New() *Collector {
+	return NewWithBuckets(nil)
+}
+
+// NewWithBuckets creates an empty collector whose latency histograms use the
+// given upper bounds in seconds. Empty buckets select DefaultBuckets.
+func NewWithBuckets(buckets []float64) *Collector {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	return &Collector{
+		start:    time.Now(),
+		buckets:  buckets,
+		requests: make(map[routeKey]*routeStats),
+		counters: make(map[string]*Counter),
+		gauges:   make(map[string]*gauge),
+	}
+}
+
+// Record adds one completed request. Responses with status >= 500 count as errors.
+func (c *Collector) Record(method, path string, status int, duration time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := routeKey{method: method, path: path}
+	stats, ok := c.requests[key]
+	if !ok {
+		stats = &routeStats{bucketCounts: make([]int64, len(c.buckets))}
+		c.requests[key] = stats
+	}
+
+	stats.count++
+	if status >= 500 {
+		stats.errors++
+	}
+	stats.totalDuration += duration
+	if duration > stats.maxDuration {
+		stats.maxDuration = duration
+	}
+
+	if i := sort.SearchFloat64s(c.buckets, duration.Seconds()); i < len(c.buckets) {
+		stats.bucketCounts[i]++
+	}
+}
+
+// Snapshot is a point-in-time view of the collected metrics.
+type Snapshot struct {
+	UptimeSeconds float64        `json:"uptime_seconds"`
+	TotalRequests int64          `json:"total_requests"`
+	Requests      []RouteMetrics `json:"requests"`
+	Goroutines    int            `json:"goroutines"`
+	Memory        MemoryMetrics  `json:"memory"`
+
+	// Counters holds the application counters, sorted by name.
+	Counters []CounterValue `json:"counters,omitempty"`
+
+	// Gauges holds the application gauges, sorted by name.
+	Gauges []GaugeValue `json:"gauges,omitempty"`
+}
+
+// CounterValue is the value of one registered Counter.
+type CounterValue struct {
+	Name  string `json:"name"`
+	Help  string `json:"help,omitempty"`
+	Value uint64 `json:"value"`
+}
+
+// GaugeValue is the value of one gauge registered with NewGaugeFunc.
+type GaugeValue struct {
+	Name  string  `json:"name"`
+	Help  string  `json:"help,omitempty"`
+	Value float64 `json:"value"`
+}
+
+// RouteMetrics summarizes requests for a single method and path.
+type RouteMetrics struct {
+	Method  string         `json:"method"`
+	Path    string         `json:"path"`
+	Count   int64          `json:"count"`
+	Errors  int64          `json:"errors"`
+	Latency LatencySummary `json:"latency"`
+
+	// Histogram is the request latency distribution, omitted from JSON.
+	Histogram Histogram `json:"-"`
+}
+
+// Histogram is a cumulative latency histogram in seconds.
+type Histogram struct {
+	Buckets []Bucket
+	Sum     float64
+	Count   int64
+}
+
+// Bucket counts the requests that took at most UpperBound seconds.
+type Bucket struct {
+	UpperBound float64
+	Count      int64
+}
+
+// LatencySummary describes request durations in milliseconds.
+type LatencySummary struct {
+	AvgMs float64 `json:"avg_ms"`
+	MaxMs float64 `json:"max_ms"`
+}
+
+// MemoryMetrics reports Go runtime memory statistics.
+type MemoryMetrics struct {
+	AllocBytes  uint64 `json:"alloc_bytes"`
+	SysBytes    uint64 `json:"sys_bytes"`
+	HeapObjects uint64 `json:"heap_objects"`
+	NumGC       uint32 `json:"num_gc"`
+}
+
+// Snapshot returns the current metrics, with routes sorted by path then method.
+func (c *Collector) Snapshot() Snapshot {
+	c.mu.Lock()
+	routes := make([]RouteMetrics, 0, len(c.requests))
+	var total int64
+	for key, stats := range c.requests {
+		total += stats.count
+		routes = append(routes, RouteMetrics{
+			Method: key.method,
+			Path:   key.path,
+			Count:  stats.count,
+			Errors: stats.errors,
+			Latency: LatencySummary{
+				AvgMs: milliseconds(stats.totalDuration) / float64(stats.count),
+				MaxMs: milliseconds(stats.maxDuration),
+			},
+			Histogram: c.histogram(stats),
+		})
+	}
+	var counters []CounterValue
+	for _, counter := range c.sortedCounters() {
+		counters = append(counters, CounterValue{Name: counter.name, Help: counter.help, Value: counter.Value()})
+	}
+	gauges := c.sortedGauges()
+	uptime := time.Since(c.start)
+	c.mu.Unlock()
+
+	// Gauge functions run unlocked so they may take their own locks
+	var gaugeValues []GaugeValue
+	for _, g := range gauges {
+		gaugeValues = append(gaugeValues, GaugeValue{Name: g.name, Help: g.help, Value: g.value()})
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	return Snapshot{
+		UptimeSeconds: uptime.Seconds(),
+		TotalRequests: total,
+		Requests:      routes,
+		Goroutines:    runtime.NumGoroutine(),
+		Memory: MemoryMetrics{
+			AllocBytes:  mem.Alloc,
+			SysBytes:    mem.Sys,
+			HeapObjects: mem.HeapObjects,
+			NumGC:       mem.NumGC,
+		},
+		Counters: counters,
+		Gauges:   gaugeValues,
+	}
+}
+
+// histogram converts a route's bucket counts to a cumulative histogram.
+// c.mu must be held.
+func (c *Collector) histogram(stats *routeStats) Histogram {
+	h := Histogram{
+		Buckets: make([]Bucket, len(c.buckets)),
+		Sum:     stats.totalDuration.Seconds(),
+		Count:   stats.count,
+	}
+
+	var cumulative int64
+	for i, upper := range c.buckets {
+		cumulative += stats.bucketCounts[i]
+		h.Buckets[i] = Bucket{UpperBound: upper, Count: cumulative}
+	}
+	return h
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
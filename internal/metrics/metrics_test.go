@@ -0,0 +1,193 @@
+package metrics
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCollectorRecord(t *testing.T) {
+	c := New()
+
+	c.Record("GET", "/health", 200, 10*time.Millisecond)
+	c.Record("GET", "/health", 200, 30*time.Millisecond)
+	c.Record("GET", "/api/info", 500, 5*time.Millisecond)
+
+	snap := c.Snapshot()
+
+	if snap.TotalRequests != 3 {
+		t.Errorf("Expected 3 total requests, got %d", snap.TotalRequests)
+	}
+
+	if len(snap.Requests) != 2 {
+		t.Fatalf("Expected 2 routes, got %d", len(snap.Requests))
+	}
+
+	// Sorted by path: /api/info, /health
+	info, health := snap.Requests[0], snap.Requests[1]
+
+	if info.Path != "/api/info" || info.Errors != 1 {
+		t.Errorf("Expected /api/info with 1 error, got %+v", info)
+	}
+
+	if health.Count != 2 {
+		t.Errorf("Expected /health count 2, got %d", health.Count)
+	}
+	if health.Latency.AvgMs != 20 || health.Latency.MaxMs != 30 {
+		t.Errorf("Expected /health avg 20ms max 30ms, got %+v", health.Latency)
+	}
+
+	if snap.Goroutines == 0 || snap.Memory.SysBytes == 0 {
+		t.Errorf("Expected runtime metrics to be populated, got %+v", snap)
+	}
+}
+
+func TestCollectorHistogram(t *testing.T) {
+	c := NewWithBuckets([]float64{0.1, 0.01, 1})
+
+	c.Record("GET", "/health", 200, 5*time.Millisecond)
+	c.Record("GET", "/health", 200, 50*time.Millisecond)
+	c.Record("GET", "/health", 200, 500*time.Millisecond)
+	c.Record("GET", "/health", 200, 2*time.Second)
+
+	h := c.Snapshot().Requests[0].Histogram
+
+	want := []Bucket{{0.01, 1}, {0.1, 2}, {1, 3}}
+	if len(h.Buckets) != len(want) {
+		t.Fatalf("Expected %d buckets, got %d", len(want), len(h.Buckets))
+	}
+	for i, bucket := range want {
+		if h.Buckets[i] != bucket {
+			t.Errorf("Bucket %d: expected %+v, got %+v", i, bucket, h.Buckets[i])
+		}
+	}
+
+	if h.Count != 4 {
+		t.Errorf("Expected count 4, got %d", h.Count)
+	}
+	if h.Sum < 2.554 || h.Sum > 2.556 {
+		t.Errorf("Expected sum 2.555s, got %v", h.Sum)
+	}
+}
+
+func TestWritePrometheus(t *testing.T) {
+	c := NewWithBuckets([]float64{0.1, 1})
+	c.Record("GET", "/health", 200, 50*time.Millisecond)
+	c.Record("GET", "/health", 500, 200*time.Millisecond)
+
+	var buf strings.Builder
+	if err := c.WritePrometheus(&buf); err != nil {
+		t.Fatalf("WritePrometheus() returned error: %v", err)
+	}
+	output := buf.String()
+
+	for _, line := range []string{
+		"# TYPE http_request_duration_seconds histogram",
+		`http_requests_total{method="GET",path="/health"} 2`,
+		`http_request_errors_total{method="GET",path="/health"} 1`,
+		`http_request_duration_seconds_bucket{method="GET",path="/health",le="0.1"} 1`,
+		`http_request_duration_seconds_bucket{method="GET",path="/health",le="1"} 2`,
+		`http_request_duration_seconds_bucket{method="GET",path="/health",le="+Inf"} 2`,
+		`http_request_duration_seconds_sum{method="GET",path="/health"} 0.25`,
+		`http_request_duration_seconds_count{method="GET",path="/health"} 2`,
+	} {
+		if !strings.Contains(output, line+"\n") {
+			t.Errorf("Expected output to contain %q\n%s", line, output)
+		}
+	}
+}
+
+func TestCollectorCounters(t *testing.T) {
+	c := New()
+
+	processed := c.NewCounter("tasks_processed_total", "Tasks processed.")
+	processed.Inc()
+	processed.Add(2)
+
+	// Registering the same name shares the counter
+	c.NewCounter("tasks_processed_total", "ignored").Inc()
+	c.NewCounter("emails_sent_total", "")
+
+	if processed.Value() != 4 {
+		t.Errorf("Expected counter value 4, got %d", processed.Value())
+	}
+
+	want := []CounterValue{
+		{Name: "emails_sent_total", Value: 0},
+		{Name: "tasks_processed_total", Help: "Tasks processed.", Value: 4},
+	}
+	if got := c.Snapshot().Counters; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected counters %+v, got %+v", want, got)
+	}
+
+	var buf strings.Builder
+	if err := c.WritePrometheus(&buf); err != nil {
+		t.Fatalf("WritePrometheus() returned error: %v", err)
+	}
+	for _, line := range []string{
+		"# HELP tasks_processed_total Tasks processed.",
+		"# TYPE tasks_processed_total counter",
+		"tasks_processed_total 4",
+		"# TYPE emails_sent_total counter",
+		"emails_sent_total 0",
+	} {
+		if !strings.Contains(buf.String(), line+"\n") {
+			t.Errorf("Expected output to contain %q\n%s", line, buf.String())
+		}
+	}
+}
+
+func TestNewCounterRejectsInvalidName(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected NewCounter to panic on an invalid name")
+		}
+	}()
+	New().NewCounter("tasks-processed", "")
+}
+
+func TestCollectorGauges(t *testing.T) {
+	c := New()
+
+	depth := 3
+	c.NewGaugeFunc("queue_depth", "Tasks waiting.", func() float64 { return float64(depth) })
+	c.NewGaugeFunc("cache_ratio", "", func() float64 { return 0.5 })
+
+	depth = 5
+	want := []GaugeValue{
+		{Name: "cache_ratio", Value: 0.5},
+		{Name: "queue_depth", Help: "Tasks waiting.", Value: 5},
+	}
+	if got := c.Snapshot().Gauges; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected gauges %+v, got %+v", want, got)
+	}
+
+	var buf strings.Builder
+	if err := c.WritePrometheus(&buf); err != nil {
+		t.Fatalf("WritePrometheus() returned error: %v", err)
+	}
+	for _, line := range []string{
+		"# HELP queue_depth Tasks waiting.",
+		"# TYPE queue_depth gauge",
+		"queue_depth 5",
+		"# TYPE cache_ratio gauge",
+		"cache_ratio 0.5",
+	} {
+		if !strings.Contains(buf.String(), line+"\n") {
+			t.Errorf("Expected output to contain %q\n%s", line, buf.String())
+		}
+	}
+}
+
+func TestNewGaugeFuncRejectsCounterName(t *testing.T) {
+	c := New()
+	c.NewCounter("tasks_total", "")
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected NewGaugeFunc to panic on a counter's name")
+		}
+	}()
+	c.NewGaugeFunc("tasks_total", "", func() float64 { return 0 })
+}
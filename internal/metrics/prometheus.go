@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// PrometheusContentType is the media type of WritePrometheus output.
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// WritePrometheus writes the current metrics in the Prometheus text
+// exposition format: request and error counters plus a latency histogram,
+// labeled by method and path, the application counters and gauges, and
+// basic runtime gauges.
+func (c *Collector) WritePrometheus(w io.Writer) error {
+	snapshot := c.Snapshot()
+	bw := bufio.NewWriter(w)
+
+	writeHeader(bw, "http_requests_total", "counter", "Total HTTP requests.")
+	for _, route := range snapshot.Requests {
+		fmt.Fprintf(bw, "http_requests_total{%s} %d\n", routeLabels(route), route.Count)
+	}
+
+	writeHeader(bw, "http_request_errors_total", "counter", "HTTP requests that returned a 5xx status.")
+	for _, route := range snapshot.Requests {
+		fmt.Fprintf(bw, "http_request_errors_total{%s} %d\n", routeLabels(route), route.Errors)
+	}
+
+	writeHeader(bw, "http_request_duration_seconds", "histogram", "HTTP request latency in seconds.")
+	for _, route := range snapshot.Requests {
+		labels := routeLabels(route)
+		for _, bucket := range route.Histogram.Buckets {
+			fmt.Fprintf(bw, "http_request_duration_seconds_bucket{%s,le=\"%s\"} %d\n",
+				labels, formatFloat(bucket.UpperBound), bucket.Count)
+		}
+		fmt.Fprintf(bw, "http_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, route.Histogram.Count)
+		fmt.Fprintf(bw, "http_request_duration_seconds_sum{%s} %s\n", labels, formatFloat(route.Histogram.Sum))
+		fmt.Fprintf(bw, "http_request_duration_seconds_count{%s} %d\n", labels, route.Histogram.Count)
+	}
+
+	for _, counter := range snapshot.Counters {
+		writeHeader(bw, counter.Name, "counter", counter.Help)
+		fmt.Fprintf(bw, "%s %d\n", counter.Name, counter.Value)
+	}
+
+	for _, gauge := range snapshot.Gauges {
+		writeHeader(bw, gauge.Name, "gauge", gauge.Help)
+		fmt.Fprintf(bw, "%s %s\n", gauge.Name, formatFloat(gauge.Value))
+	}
+
+	writeHeader(bw, "process_uptime_seconds", "gauge", "Seconds since the collector was created.")
+	fmt.Fprintf(bw, "process_uptime_seconds %s\n", formatFloat(snapshot.UptimeSeconds))
+
+	writeHeader(bw, "go_goroutines", "gauge", "Number of goroutines.")
+	fmt.Fprintf(bw, "go_goroutines %d\n", snapshot.Goroutines)
+
+	writeHeader(bw, "go_memstats_alloc_bytes", "gauge", "Bytes of allocated heap objects.")
+	fmt.Fprintf(bw, "go_memstats_alloc_bytes %d\n", snapshot.Memory.AllocBytes)
+
+	return bw.Flush()
+}
+
+// writeHeader writes the HELP and TYPE comments for a metric, leaving out
+// HELP when there is no help text.
+func writeHeader(w io.Writer, name, kind, help string) {
+	if help != "" {
+		fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	}
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, kind)
+}
+
+func routeLabels(route RouteMetrics) string {
+	return fmt.Sprintf("method=\"%s\",path=\"%s\"", escapeLabel(route.Method), escapeLabel(route.Path))
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(value string) string {
+	return labelEscaper.Replace(value)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
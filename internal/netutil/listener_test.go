@@ -0,0 +1,118 @@
+package netutil
+
+import (
+	"bufio"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestLimitListenerHoldsExcessConnections(t *testing.T) {
+	const limit = 2
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	limited := LimitListener(ln, limit)
+	defer limited.Close()
+
+	// Echo server: replies to each line on the connection
+	go func() {
+		for {
+			conn, err := limited.Accept()
+			if err != nil {
+				return
+			}
+			go func(c net.Conn) {
+				defer c.Close()
+				scanner := bufio.NewScanner(c)
+				for scanner.Scan() {
+					if _, err := c.Write([]byte(scanner.Text() + "\n")); err != nil {
+						return
+					}
+				}
+			}(conn)
+		}
+	}()
+
+	conns := make([]net.Conn, limit+1)
+	for i := range conns {
+		c, err := net.Dial("tcp", ln.Addr().String())
+		if err != nil {
+			t.Fatalf("Dial %d failed: %v", i, err)
+		}
+		defer c.Close()
+		conns[i] = c
+	}
+
+	// Connections within the limit are served
+	for i := 0; i < limit; i++ {
+		if !echoes(conns[i], 2*time.Second) {
+			t.Fatalf("Connection %d within limit was not served", i)
+		}
+	}
+
+	// The excess connection is held until a slot frees up
+	if echoes(conns[limit], 100*time.Millisecond) {
+		t.Fatal("Connection beyond limit was served before a slot was freed")
+	}
+
+	conns[0].Close()
+
+	if !readEcho(conns[limit], 2*time.Second) {
+		t.Error("Held connection was not served after a slot was freed")
+	}
+}
+
+func TestLimitListenerCloseUnblocksAccept(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	limited := LimitListener(ln, 1)
+
+	c, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer c.Close()
+
+	// Take the only slot
+	if _, err := limited.Accept(); err != nil {
+		t.Fatalf("Accept failed: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := limited.Accept()
+		done <- err
+	}()
+
+	limited.Close()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("Expected error from Accept after Close")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Accept did not return after Close")
+	}
+}
+
+// echoes writes a line and reports whether it is echoed back within timeout.
+func echoes(c net.Conn, timeout time.Duration) bool {
+	if _, err := c.Write([]byte("ping\n")); err != nil {
+		return false
+	}
+	return readEcho(c, timeout)
+}
+
+func readEcho(c net.Conn, timeout time.Duration) bool {
+	if err := c.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return false
+	}
+	line, err := bufio.NewReader(c).ReadString('\n')
+	return err == nil && line == "ping\n"
+}
@@ -0,0 +1,67 @@
+// Package store provides the database access layer.
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/your-org/go-template-project/internal/config"
+)
+
+// ErrNoDatabaseURL is returned by Open when DATABASE_URL is not configured.
+var ErrNoDatabaseURL = errors.New("DATABASE_URL is not set")
+
+// Store wraps the database connection pool used by the application.
+type Store struct {
+	db *sql.DB
+}
+
+// Open connects to cfg.DatabaseURL using the named database/sql driver.
+// The driver must be registered by importing it, for example:
+//
+//	import _ "github.com/jackc/pgx/v5/stdlib" // registers "pgx"
+//
+// A nil cfg has no DatabaseURL, like config.Default().
+func Open(ctx context.Context, driverName string, cfg *config.Config) (*Store, error) {
+	if cfg == nil || cfg.DatabaseURL == "" {
+		return nil, ErrNoDatabaseURL
+	}
+
+	db, err := sql.Open(driverName, cfg.DatabaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	s := New(db)
+	if err := s.Ping(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// New wraps an existing connection pool.
+func New(db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
+// Ping verifies the database is reachable.
+func (s *Store) Ping(ctx context.Context) error {
+	if err := s.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to reach database: %w", err)
+	}
+	return nil
+}
+
+// DB returns the underlying connection pool for queries.
+func (s *Store) DB() *sql.DB {
+	return s.db
+}
+
+// Close releases the connection pool.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
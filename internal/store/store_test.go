@@ -0,0 +1,58 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/your-org/go-template-project/internal/config"
+)
+
+// fakeDriver is a minimal database/sql driver whose connections fail to
+// open when the DSN is "unreachable".
+type fakeDriver struct{}
+
+func (fakeDriver) Open(dsn string) (driver.Conn, error) {
+	if dsn == "unreachable" {
+		return nil, errors.New("connection refused")
+	}
+	return fakeConn{}, nil
+}
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not implemented") }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not implemented") }
+
+func init() {
+	sql.Register("fake", fakeDriver{})
+}
+
+func TestOpen(t *testing.T) {
+	s, err := Open(context.Background(), "fake", &config.Config{DatabaseURL: "fake://db"})
+	if err != nil {
+		t.Fatalf("Open() returned error: %v", err)
+	}
+	defer s.Close()
+
+	if s.DB() == nil {
+		t.Error("Expected DB() to return the connection pool")
+	}
+}
+
+func TestOpenWithoutDatabaseURL(t *testing.T) {
+	_, err := Open(context.Background(), "fake", &config.Config{})
+	if !errors.Is(err, ErrNoDatabaseURL) {
+		t.Errorf("Expected ErrNoDatabaseURL, got %v", err)
+	}
+}
+
+func TestOpenUnreachable(t *testing.T) {
+	_, err := Open(context.Background(), "fake", &config.Config{DatabaseURL: "unreachable"})
+	if err == nil {
+		t.Error("Expected error for unreachable database")
+	}
+}
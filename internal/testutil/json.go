@@ -0,0 +1,67 @@
+// Package testutil holds assertions shared by the unit, integration, and
+// end-to-end tests.
+package testutil
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// Present matches any value in AssertJSON, including null, so a key can be
+// required without coupling the test to its value (timestamps, versions).
+var Present = present{}
+
+type present struct{}
+
+// DecodeJSON unmarshals body into a map, failing the test immediately if
+// it isn't a JSON object.
+func DecodeJSON(t testing.TB, body []byte) map[string]any {
+	t.Helper()
+
+	var got map[string]any
+	if err := json.Unmarshal(body, &got); err != nil || got == nil {
+		t.Fatalf("Expected a JSON object, got %q", body)
+	}
+	return got
+}
+
+// AssertJSON decodes body and reports an error for every key in want that is
+// missing or holds a different value. Expected values are compared after a
+// JSON round trip, so want can use ints, structs or nested maps. It returns
+// the decoded object for further checks.
+func AssertJSON(t testing.TB, body []byte, want map[string]any) map[string]any {
+	t.Helper()
+
+	got := DecodeJSON(t, body)
+	for key, wantValue := range want {
+		gotValue, ok := got[key]
+		if !ok {
+			t.Errorf("Expected key %q in %s", key, body)
+			continue
+		}
+		if wantValue == Present {
+			continue
+		}
+
+		normalized, err := roundTrip(wantValue)
+		if err != nil {
+			t.Fatalf("Cannot compare expected %q value %v: %v", key, wantValue, err)
+		}
+		if !reflect.DeepEqual(gotValue, normalized) {
+			t.Errorf("Expected %q to be %v, got %v", key, normalized, gotValue)
+		}
+	}
+	return got
+}
+
+// roundTrip converts v to the types encoding/json decodes into any.
+func roundTrip(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	err = json.Unmarshal(data, &out)
+	return out, err
+}
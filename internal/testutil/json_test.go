@@ -0,0 +1,89 @@
+package testutil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// recorder captures failures instead of failing the enclosing test.
+type recorder struct {
+	testing.TB
+	errors []string
+	fatal  bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+	r.fatal = true
+	// Stop the helper the way t.Fatalf would
+	panic(r)
+}
+
+// run calls fn with a recorder, recovering from Fatalf.
+func run(fn func(t testing.TB)) (r *recorder) {
+	r = &recorder{}
+	defer func() {
+		if v := recover(); v != nil && v != r {
+			panic(v)
+		}
+	}()
+	fn(r)
+	return r
+}
+
+func TestAssertJSON(t *testing.T) {
+	body := []byte(`{"status":"healthy","count":3,"timestamp":"2024-01-01T00:00:00Z","tags":["a","b"],"meta":{"ok":true}}`)
+
+	r := run(func(t testing.TB) {
+		AssertJSON(t, body, map[string]any{
+			"status":    "healthy",
+			"count":     3,
+			"timestamp": Present,
+			"tags":      []string{"a", "b"},
+			"meta":      map[string]bool{"ok": true},
+		})
+	})
+	if len(r.errors) != 0 {
+		t.Errorf("Expected matching body to pass, got %v", r.errors)
+	}
+}
+
+func TestAssertJSONReportsMismatches(t *testing.T) {
+	body := []byte(`{"status":"degraded","count":3}`)
+
+	r := run(func(t testing.TB) {
+		AssertJSON(t, body, map[string]any{
+			"status":  "healthy",
+			"count":   3,
+			"version": Present,
+		})
+	})
+
+	if len(r.errors) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", r.errors)
+	}
+	joined := strings.Join(r.errors, "\n")
+	for _, want := range []string{`"status" to be healthy, got degraded`, `key "version"`} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("Expected an error mentioning %s, got:\n%s", want, joined)
+		}
+	}
+}
+
+func TestDecodeJSONRejectsNonObjects(t *testing.T) {
+	for _, body := range []string{``, `not json`, `[1,2]`, `null`} {
+		r := run(func(t testing.TB) {
+			DecodeJSON(t, []byte(body))
+		})
+		if !r.fatal {
+			t.Errorf("Expected %q to fail the test", body)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package tlsutil
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// CertReloader serves a TLS certificate loaded from disk and reloads it when
+// the certificate or key file changes, so rotated certificates (e.g. Let's
+// Encrypt renewals) are picked up without restarting the server.
+//
+// Existing connections keep the certificate they negotiated; only new
+// handshakes see the reloaded one.
+type CertReloader struct {
+	certFile string
+	keyFile  string
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	certMtime time.Time
+	keyMtime  time.Time
+}
+
+// NewCertReloader loads the certificate pair and returns a reloader for it.
+func NewCertReloader(certFile, keyFile string) (*CertReloader, error) {
+	r := &CertReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Reload reads the certificate pair from disk unconditionally.
+// On error the previously loaded certificate stays in use.
+func (r *CertReloader) Reload() error {
+	certMtime, keyMtime, err := r.modTimes()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS key pair: %w", err)
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.certMtime = certMtime
+	r.keyMtime = keyMtime
+	r.mu.Unlock()
+
+	return nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate. It reloads the pair
+// first if either file has been modified since the last load.
+func (r *CertReloader) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	if r.changed() {
+		// A half-written rotation fails to parse; keep serving the old
+		// certificate and retry on the next handshake.
+		_ = r.Reload()
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// TLSConfig returns a server TLS configuration backed by the reloader.
+func (r *CertReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: r.GetCertificate,
+	}
+}
+
+// changed reports whether either file's modification time differs from the
+// loaded pair.
+func (r *CertReloader) changed() bool {
+	certMtime, keyMtime, err := r.modTimes()
+	if err != nil {
+		return false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return !certMtime.Equal(r.certMtime) || !keyMtime.Equal(r.keyMtime)
+}
+
+func (r *CertReloader) modTimes() (time.Time, time.Time, error) {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to stat TLS certificate: %w", err)
+	}
+
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to stat TLS key: %w", err)
+	}
+
+	return certInfo.ModTime(), keyInfo.ModTime(), nil
+}
@@ -0,0 +1,256 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// otlpBatchSize is the number of spans that triggers an immediate export.
+	otlpBatchSize = 128
+
+	// otlpFlushInterval bounds how long a span waits before being exported.
+	otlpFlushInterval = 5 * time.Second
+)
+
+// FromEnv returns a tracer exporting to OTEL_EXPORTER_OTLP_ENDPOINT, and a
+// shutdown function that flushes pending spans. Without the variable it
+// returns a nil (no-op) tracer.
+func FromEnv(serviceName string) (*Tracer, func(context.Context) error) {
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	if endpoint == "" {
+		return nil, func(context.Context) error { return nil }
+	}
+
+	if name := os.Getenv("OTEL_SERVICE_NAME"); name != "" {
+		serviceName = name
+	}
+
+	exporter := NewOTLPExporter(endpoint, serviceName)
+	return New(exporter), exporter.Shutdown
+}
+
+// OTLPExporter batches spans and posts them as OTLP/HTTP JSON to
+// <endpoint>/v1/traces.
+type OTLPExporter struct {
+	url         string
+	serviceName string
+	client      *http.Client
+
+	// mu guards closed so Export never sends on spans after Shutdown
+	// closes it.
+	mu     sync.RWMutex
+	closed bool
+	spans  chan *Span
+	done   chan struct{}
+}
+
+// NewOTLPExporter starts an exporter sending to endpoint.
+func NewOTLPExporter(endpoint, serviceName string) *OTLPExporter {
+	e := &OTLPExporter{
+		url:         strings.TrimSuffix(endpoint, "/") + "/v1/traces",
+		serviceName: serviceName,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		spans:       make(chan *Span, otlpBatchSize*4),
+		done:        make(chan struct{}),
+	}
+	go e.loop()
+	return e
+}
+
+// Export queues span for sending, dropping it if the queue is full so
+// tracing never blocks request handling. Spans that end after Shutdown,
+// such as requests still draining, are dropped.
+func (e *OTLPExporter) Export(span *Span) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.closed {
+		return
+	}
+	select {
+	case e.spans <- span:
+	default:
+	}
+}
+
+// Shutdown flushes queued spans and stops the exporter.
+func (e *OTLPExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	if !e.closed {
+		e.closed = true
+		close(e.spans)
+	}
+	e.mu.Unlock()
+
+	select {
+	case <-e.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (e *OTLPExporter) loop() {
+	defer close(e.done)
+
+	ticker := time.NewTicker(otlpFlushInterval)
+	defer ticker.Stop()
+
+	var batch []*Span
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.send(batch); err != nil {
+			log.Printf("Trace export failed: %v", err)
+		}
+		batch = nil
+	}
+
+	for {
+		select {
+		case span, ok := <-e.spans:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, span)
+			if len(batch) >= otlpBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (e *OTLPExporter) send(spans []*Span) error {
+	body, err := json.Marshal(e.payload(spans))
+	if err != nil {
+		return err
+	}
+
+	resp, err := e.client.Post(e.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("collector returned %s", resp.Status)
+	}
+	return nil
+}
+
+// OTLP/JSON wire types (opentelemetry-proto, JSON mapping).
+type (
+	otlpPayload struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpAttribute `json:"attributes"`
+	}
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+	otlpSpan struct {
+		TraceID      string          `json:"traceId"`
+		SpanID       string          `json:"spanId"`
+		ParentSpanID string          `json:"parentSpanId,omitempty"`
+		Name         string          `json:"name"`
+		Kind         int             `json:"kind"`
+		Start        string          `json:"startTimeUnixNano"`
+		End          string          `json:"endTimeUnixNano"`
+		Attributes   []otlpAttribute `json:"attributes,omitempty"`
+		Status       otlpStatus      `json:"status"`
+	}
+	otlpStatus struct {
+		Code int `json:"code"`
+	}
+	otlpAttribute struct {
+		Key   string    `json:"key"`
+		Value otlpValue `json:"value"`
+	}
+	otlpValue struct {
+		StringValue *string `json:"stringValue,omitempty"`
+		IntValue    *string `json:"intValue,omitempty"`
+		BoolValue   *bool   `json:"boolValue,omitempty"`
+	}
+)
+
+const (
+	otlpSpanKindServer = 2
+	otlpStatusError    = 2
+)
+
+func (e *OTLPExporter) payload(spans []*Span) otlpPayload {
+	out := make([]otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		s := otlpSpan{
+			TraceID: span.Context.TraceID.String(),
+			SpanID:  span.Context.SpanID.String(),
+			Name:    span.Name,
+			Kind:    otlpSpanKindServer,
+			Start:   strconv.FormatInt(span.StartTime.UnixNano(), 10),
+		}
+		if span.Parent.IsValid() {
+			s.ParentSpanID = span.Parent.String()
+		}
+		if span.Error {
+			s.Status.Code = otlpStatusError
+		}
+
+		span.mu.Lock()
+		s.End = strconv.FormatInt(span.EndTime.UnixNano(), 10)
+		for key, value := range span.Attributes {
+			s.Attributes = append(s.Attributes, otlpAttribute{Key: key, Value: toOTLPValue(value)})
+		}
+		span.mu.Unlock()
+
+		out = append(out, s)
+	}
+
+	return otlpPayload{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: []otlpAttribute{
+			{Key: "service.name", Value: toOTLPValue(e.serviceName)},
+		}},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: "github.com/your-org/go-template-project/internal/tracing"},
+			Spans: out,
+		}},
+	}}}
+}
+
+func toOTLPValue(value any) otlpValue {
+	switch v := value.(type) {
+	case int:
+		s := strconv.Itoa(v)
+		return otlpValue{IntValue: &s}
+	case int64:
+		s := strconv.FormatInt(v, 10)
+		return otlpValue{IntValue: &s}
+	case bool:
+		return otlpValue{BoolValue: &v}
+	default:
+		s := fmt.Sprint(v)
+		return otlpValue{StringValue: &s}
+	}
+}
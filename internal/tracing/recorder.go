@@ -0,0 +1,23 @@
+package tracing
+
+import "sync"
+
+// Recorder is an in-memory exporter, useful in tests.
+type Recorder struct {
+	mu    sync.Mutex
+	spans []*Span
+}
+
+// Export stores span.
+func (r *Recorder) Export(span *Span) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, span)
+}
+
+// Spans returns the spans recorded so far.
+func (r *Recorder) Spans() []*Span {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*Span(nil), r.spans...)
+}
@@ -0,0 +1,146 @@
+// Package tracing emits request spans with W3C trace-context propagation.
+//
+// It is not the OpenTelemetry SDK but a minimal exporter that keeps the
+// template dependency-free: sampled spans are posted as OTLP/HTTP JSON when
+// OTEL_EXPORTER_OTLP_ENDPOINT is set, and tracing is a no-op otherwise. It
+// speaks the OTLP wire format, so any OpenTelemetry collector can receive
+// the spans; switch to go.opentelemetry.io/otel when you need its samplers,
+// span events or other exporters.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// TraceID identifies a trace across services.
+type TraceID [16]byte
+
+// SpanID identifies a span within a trace.
+type SpanID [8]byte
+
+func (t TraceID) String() string { return hex.EncodeToString(t[:]) }
+func (s SpanID) String() string  { return hex.EncodeToString(s[:]) }
+
+// IsValid reports whether the ID is non-zero.
+func (t TraceID) IsValid() bool { return t != TraceID{} }
+
+// IsValid reports whether the ID is non-zero.
+func (s SpanID) IsValid() bool { return s != SpanID{} }
+
+// SpanContext is the propagated identity of a span.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+// IsValid reports whether both IDs are set.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// Exporter receives finished spans.
+type Exporter interface {
+	Export(span *Span)
+}
+
+// Tracer starts spans and hands them to its exporter when they end. A nil
+// *Tracer is valid and disables tracing.
+type Tracer struct {
+	exporter Exporter
+}
+
+// New creates a tracer that sends finished spans to exporter.
+func New(exporter Exporter) *Tracer {
+	return &Tracer{exporter: exporter}
+}
+
+// Start begins a span named name. It continues the trace found in ctx (see
+// ContextWithRemoteSpanContext) or starts a new one, and returns a context
+// carrying the new span.
+func (t *Tracer) Start(ctx context.Context, name string) (context.Context, *Span) {
+	span := &Span{
+		tracer:     t,
+		Name:       name,
+		StartTime:  time.Now(),
+		Attributes: make(map[string]any),
+	}
+
+	if parent := SpanContextFromContext(ctx); parent.IsValid() {
+		span.Context.TraceID = parent.TraceID
+		span.Context.Sampled = parent.Sampled
+		span.Parent = parent.SpanID
+	} else {
+		span.Context.TraceID = newTraceID()
+		span.Context.Sampled = true
+	}
+	span.Context.SpanID = newSpanID()
+
+	return context.WithValue(ctx, spanContextKey{}, span.Context), span
+}
+
+// Span is a timed operation within a trace.
+type Span struct {
+	tracer *Tracer
+
+	Name      string
+	Context   SpanContext
+	Parent    SpanID
+	StartTime time.Time
+	EndTime   time.Time
+	Error     bool
+
+	mu         sync.Mutex
+	Attributes map[string]any
+}
+
+// SetAttribute records a string, int or bool attribute on the span.
+func (s *Span) SetAttribute(key string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Attributes[key] = value
+}
+
+// End finishes the span and exports it unless the trace isn't sampled.
+func (s *Span) End() {
+	s.mu.Lock()
+	s.EndTime = time.Now()
+	s.mu.Unlock()
+
+	if !s.Context.Sampled {
+		return
+	}
+	if s.tracer != nil && s.tracer.exporter != nil {
+		s.tracer.exporter.Export(s)
+	}
+}
+
+type spanContextKey struct{}
+
+// SpanContextFromContext returns the span context carried by ctx, if any.
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	sc, _ := ctx.Value(spanContextKey{}).(SpanContext)
+	return sc
+}
+
+// ContextWithRemoteSpanContext returns ctx carrying sc, typically extracted
+// from an incoming request, so the next Start continues its trace.
+func ContextWithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+func newTraceID() TraceID {
+	var id TraceID
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+func newSpanID() SpanID {
+	var id SpanID
+	_, _ = rand.Read(id[:])
+	return id
+}
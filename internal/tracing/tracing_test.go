@@ -0,0 +1,151 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestExtractInjectRoundTrip(t *testing.T) {
+	h := http.Header{}
+	h.Set(TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	sc, ok := Extract(h)
+	if !ok {
+		t.Fatal("Expected valid traceparent to be extracted")
+	}
+	if !sc.Sampled {
+		t.Error("Expected sampled flag to be set")
+	}
+
+	out := http.Header{}
+	Inject(ContextWithRemoteSpanContext(context.Background(), sc), out)
+	if got := out.Get(TraceparentHeader); got != h.Get(TraceparentHeader) {
+		t.Errorf("Expected %s, got %s", h.Get(TraceparentHeader), got)
+	}
+}
+
+func TestExtractRejectsInvalid(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"garbage",
+		"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902-01",
+	} {
+		h := http.Header{}
+		h.Set(TraceparentHeader, value)
+		if _, ok := Extract(h); ok {
+			t.Errorf("Expected %q to be rejected", value)
+		}
+	}
+}
+
+func TestStartNewTrace(t *testing.T) {
+	recorder := &Recorder{}
+	tracer := New(recorder)
+
+	ctx, span := tracer.Start(context.Background(), "root")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+	span.End()
+
+	if !span.Context.IsValid() {
+		t.Fatal("Expected root span to have a valid context")
+	}
+	if child.Context.TraceID != span.Context.TraceID {
+		t.Error("Expected child to share the root trace ID")
+	}
+	if child.Parent != span.Context.SpanID {
+		t.Error("Expected child parent to be the root span")
+	}
+	if got := len(recorder.Spans()); got != 2 {
+		t.Errorf("Expected 2 recorded spans, got %d", got)
+	}
+}
+
+func TestUnsampledSpansAreNotExported(t *testing.T) {
+	recorder := &Recorder{}
+	tracer := New(recorder)
+
+	parent := SpanContext{TraceID: TraceID{1}, SpanID: SpanID{1}, Sampled: false}
+	_, span := tracer.Start(ContextWithRemoteSpanContext(context.Background(), parent), "GET /health")
+	span.End()
+
+	if span.EndTime.IsZero() {
+		t.Error("Expected End to record the end time")
+	}
+	if got := len(recorder.Spans()); got != 0 {
+		t.Errorf("Expected an unsampled span not to be exported, got %d", got)
+	}
+}
+
+func TestFromEnvWithoutEndpointIsNoop(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+
+	tracer, shutdown := FromEnv("test")
+	if tracer != nil {
+		t.Error("Expected nil tracer without an endpoint")
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("Expected no-op shutdown, got %v", err)
+	}
+}
+
+func TestOTLPExporterFlushesOnShutdown(t *testing.T) {
+	bodies := make(chan []byte, 1)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" {
+			t.Errorf("Expected /v1/traces, got %s", r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		bodies <- body
+	}))
+	defer collector.Close()
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", collector.URL)
+	t.Setenv("OTEL_SERVICE_NAME", "")
+
+	tracer, shutdown := FromEnv("test-service")
+	_, span := tracer.Start(context.Background(), "GET /health")
+	span.SetAttribute("http.response.status_code", 200)
+	span.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdown(ctx); err != nil {
+		t.Fatalf("shutdown() returned error: %v", err)
+	}
+
+	var payload otlpPayload
+	if err := json.Unmarshal(<-bodies, &payload); err != nil {
+		t.Fatalf("Failed to unmarshal payload: %v", err)
+	}
+	spans := payload.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 1 || spans[0].Name != "GET /health" {
+		t.Errorf("Expected one GET /health span, got %+v", spans)
+	}
+	if spans[0].TraceID != span.Context.TraceID.String() {
+		t.Errorf("Expected trace ID %s, got %s", span.Context.TraceID, spans[0].TraceID)
+	}
+}
+
+func TestOTLPExporterDropsSpansAfterShutdown(t *testing.T) {
+	exporter := NewOTLPExporter("http://127.0.0.1:0", "test-service")
+	if err := exporter.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() returned error: %v", err)
+	}
+
+	// A request still draining ends its span after shutdown
+	_, span := New(exporter).Start(context.Background(), "GET /slow")
+	span.End()
+
+	if err := exporter.Shutdown(context.Background()); err != nil {
+		t.Errorf("Expected a second Shutdown to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_health_checks_checked_at;
+DROP TABLE IF EXISTS health_checks;
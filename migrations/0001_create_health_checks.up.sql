@@ -0,0 +1,11 @@
+-- Example migration: mirrors the table created by docker/init.sql
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE IF NOT EXISTS health_checks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    status VARCHAR(50) NOT NULL,
+    checked_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    details JSONB
+);
+
+CREATE INDEX IF NOT EXISTS idx_health_checks_checked_at ON health_checks(checked_at);
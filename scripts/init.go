@@ -2,31 +2,140 @@ package main
 
 import (
 	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"io/fs"
 	"log"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"strings"
 	"text/template"
 	"time"
+	"unicode"
 )
 
 // ProjectConfig holds the configuration for project initialization.
+// The json tags are the keys accepted in an --answers file.
 type ProjectConfig struct {
-	ProjectName    string
-	ModulePath     string
-	Description    string
-	Author         string
-	Email          string
-	License        string
-	EnableCLI      bool
-	EnableServer   bool
-	EnableWorker   bool
-	EnableDocs     bool
-	EnableE2ETests bool
-	GitRemote      string
+	ProjectName     string `json:"project_name"`
+	ModulePath      string `json:"module_path"`
+	Description     string `json:"description"`
+	Author          string `json:"author"`
+	Email           string `json:"email"`
+	License         string `json:"license"`
+	GoVersion       string `json:"go_version"`
+	EnableCLI       bool   `json:"enable_cli"`
+	EnableServer    bool   `json:"enable_server"`
+	EnableWorker    bool   `json:"enable_worker"`
+	EnableScheduler bool   `json:"enable_scheduler"`
+	EnableGRPC      bool   `json:"enable_grpc"`
+	EnableDatabase  bool   `json:"enable_database"`
+	EnableDocs      bool   `json:"enable_docs"`
+	// Test tiers beyond unit tests, which are always kept
+	EnableIntegrationTests bool   `json:"enable_integration_tests"`
+	EnableSmokeTests       bool   `json:"enable_smoke_tests"`
+	EnableE2ETests         bool   `json:"enable_e2e_tests"`
+	GitRemote              string `json:"git_remote"`
+}
+
+// initOptions holds command-line flags for the init script.
+type initOptions struct {
+	// GRPC sets the default answer for the gRPC service prompt.
+	GRPC bool
+
+	// NoEmoji replaces emoji in console output with plain ASCII labels.
+	NoEmoji bool
+
+	// GoVersion sets the default for the go.mod go directive prompt.
+	GoVersion string
+
+	// GitTimeout bounds each attempt at the initial git commit.
+	GitTimeout time.Duration
+
+	// SkipGit suppresses git init and the initial commit.
+	SkipGit bool
+
+	// KeepInit leaves the init script and its tests in place afterwards.
+	KeepInit bool
+
+	// AnswersFile, when set, is a JSON file of ProjectConfig answers used
+	// instead of the interactive prompts.
+	AnswersFile string
+
+	// Verbose prints each initialization step with how long it took.
+	Verbose bool
+
+	// Force runs init even when the directory has local changes.
+	Force bool
+
+	// Governance generates CONTRIBUTING.md and .github/CODEOWNERS seeded
+	// with the author.
+	Governance bool
+
+	// ReadmeTemplate is a text/template file rendered with TemplateData in
+	// place of the built-in README template.
+	ReadmeTemplate string
+
+	// OutputDir, when set, is a new directory the template is copied into
+	// and initialized in, leaving the checkout init runs from untouched.
+	OutputDir string
+
+	// readmeTemplate is ReadmeTemplate parsed by parseFlags, so the file is
+	// checked before anything changes and may live in a directory init
+	// removes.
+	readmeTemplate *template.Template
+}
+
+// out receives all console output. main swaps it for a plainWriter when
+// emoji are disabled.
+var out io.Writer = os.Stdout
+
+// verbose enables step timing output. main sets it from --verbose.
+var verbose bool
+
+// emojiReplacer maps the emoji used in console output to ASCII labels.
+var emojiReplacer = strings.NewReplacer(
+	"🚀 ", "",
+	"📋 ", "",
+	"✅ ", "[ok] ",
+	"❌ ", "[cancelled] ",
+	"⚠️  ", "[warn] ",
+	"ℹ️  ", "[info] ",
+	"🗑️  ", "[remove] ",
+	"🧹 ", "[cleanup] ",
+	"↩️  ", "[restore] ",
+	"⏱️  ", "[step] ",
+)
+
+// plainWriter strips emoji from everything written through it, replacing the
+// known ones with ASCII labels. Useful for terminals and log files that
+// can't render them.
+type plainWriter struct {
+	w io.Writer
+}
+
+func (p plainWriter) Write(b []byte) (int, error) {
+	plain := strings.Map(func(r rune) rune {
+		// Drop any remaining pictographs and emoji variation selectors
+		if unicode.Is(unicode.So, r) || r == '\uFE0F' {
+			return -1
+		}
+		return r
+	}, emojiReplacer.Replace(string(b)))
+
+	if _, err := io.WriteString(p.w, plain); err != nil {
+		return 0, err
+	}
+	return len(b), nil
 }
 
 // TemplateData holds data for template rendering.
@@ -36,45 +145,147 @@ type TemplateData struct {
 }
 
 const (
-	defaultLicense = "MIT"
-	defaultAuthor  = "Your Name"
-	defaultEmail   = "your.email@example.com"
+	defaultLicense   = "MIT"
+	defaultGoVersion = "1.23"
+	defaultAuthor    = "Your Name"
+	defaultEmail     = "your.email@example.com"
+
+	defaultDescription = "A Go application built from go-template-project"
+
+	// maxPromptAttempts is how often an invalid answer is re-asked before
+	// init gives up.
+	maxPromptAttempts = 3
+
+	// defaultGitTimeout bounds each initial commit attempt, including hooks.
+	defaultGitTimeout = 10 * time.Second
+
+	// templateModulePath is the module path shipped with the template. Once init
+	// has rewritten go.mod it no longer matches, which marks the project as initialized.
+	templateModulePath = "github.com/your-org/go-template-project"
 
 	// Regex patterns for validation
+	goVersionPattern   = `^1\.[0-9]+(\.[0-9]+)?$`
 	projectNamePattern = `^[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9]$`
-	modulePathPattern  = `^[a-zA-Z0-9][a-zA-Z0-9-_.]*[a-zA-Z0-9]/` +
-		`[a-zA-Z0-9][a-zA-Z0-9-_.]*[a-zA-Z0-9]/[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9]$`
+	// The first module path element is a host name (must contain a dot);
+	// the remaining elements follow the Go module path element rules.
+	moduleHostPattern    = `^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)+$`
+	moduleElementPattern = `^[a-zA-Z0-9_~-]([a-zA-Z0-9._~-]*[a-zA-Z0-9_~-])?$`
 )
 
 func main() {
-	fmt.Println("🚀 Go Template Project Initialization")
-	fmt.Println("=====================================")
-	fmt.Println()
+	opts, err := parseFlags(os.Args[1:])
+	if err != nil {
+		log.Fatalf("Invalid arguments: %v", err)
+	}
+
+	if opts.NoEmoji {
+		out = plainWriter{w: os.Stdout}
+	}
+	verbose = opts.Verbose
+
+	fmt.Fprintln(out, "🚀 Go Template Project Initialization")
+	fmt.Fprintln(out, "=====================================")
+	fmt.Fprintln(out)
+
+	if err := ensureNotInitialized(); err != nil {
+		log.Fatalf("Cannot initialize project: %v", err)
+	}
+	if err := ensurePristine(opts.Force); err != nil {
+		log.Fatalf("Cannot initialize project: %v", err)
+	}
 
-	config, err := gatherProjectInfo()
+	var config *ProjectConfig
+	if opts.AnswersFile != "" {
+		config, err = loadAnswers(opts.AnswersFile, opts)
+		if err == nil {
+			printSummary(config)
+		}
+	} else {
+		config, err = gatherProjectInfo(bufio.NewReader(os.Stdin), opts)
+	}
 	if err != nil {
 		log.Fatalf("Failed to gather project info: %v", err)
 	}
 
-	if err := initializeProject(config); err != nil {
+	if err := initializeProject(config, opts); err != nil {
 		log.Fatalf("Failed to initialize project: %v", err)
 	}
 
-	fmt.Println("\n✅ Project initialized successfully!")
-	fmt.Println("\nNext steps:")
-	fmt.Println("  1. Review the generated files")
-	fmt.Println("  2. Run 'make setup' to install development tools")
-	fmt.Println("  3. Run 'make check' to verify everything works")
+	fmt.Fprintln(out, "\n✅ Project initialized successfully!")
+	if opts.OutputDir != "" {
+		fmt.Fprintf(out, "   Your project is in %s\n", opts.OutputDir)
+	}
+	fmt.Fprintln(out, "\nNext steps:")
+	fmt.Fprintln(out, "  1. Review the generated files")
+	fmt.Fprintln(out, "  2. Run 'make setup' to install development tools")
+	fmt.Fprintln(out, "  3. Run 'make check' to verify everything works")
 	if config.EnableDocs {
-		fmt.Println("  4. Update documentation in docs/ to match your project")
-		fmt.Println("  5. Start coding!")
+		fmt.Fprintln(out, "  4. Update documentation in docs/ to match your project")
+		fmt.Fprintln(out, "  5. Start coding!")
 	} else {
-		fmt.Println("  4. Start coding!")
+		fmt.Fprintln(out, "  4. Start coding!")
+	}
+}
+
+// parseFlags parses the init script's command-line flags.
+func parseFlags(args []string) (*initOptions, error) {
+	opts := &initOptions{}
+
+	fs := flag.NewFlagSet("init", flag.ContinueOnError)
+	fs.BoolVar(&opts.GRPC, "grpc", false, "Include the gRPC service by default")
+	fs.StringVar(&opts.GoVersion, "go-version", "",
+		"Go version for the go.mod go directive (default: detected local toolchain)")
+	fs.BoolVar(&opts.NoEmoji, "no-emoji", os.Getenv("NO_EMOJI") != "",
+		"Use plain ASCII output instead of emoji (or set NO_EMOJI=1)")
+	fs.BoolVar(&opts.SkipGit, "skip-git", os.Getenv("SKIP_GIT_INIT") != "",
+		"Skip git init and the initial commit (or set SKIP_GIT_INIT=1)")
+	fs.BoolVar(&opts.KeepInit, "keep-init", false,
+		"Keep scripts/init.go after initialization instead of deleting it")
+	fs.DurationVar(&opts.GitTimeout, "git-timeout", defaultGitTimeout,
+		"Timeout for each initial git commit attempt (pre-commit hooks run inside it)")
+	fs.StringVar(&opts.AnswersFile, "answers", "",
+		"JSON file of answers to use instead of the interactive prompts")
+	fs.BoolVar(&opts.Verbose, "verbose", false,
+		"Print each initialization step with its duration")
+	fs.BoolVar(&opts.Force, "force", false,
+		"Initialize even if the directory has uncommitted changes or a replaced README")
+	fs.BoolVar(&opts.Governance, "governance", false,
+		"Generate CONTRIBUTING.md and .github/CODEOWNERS owned by the author")
+	fs.StringVar(&opts.ReadmeTemplate, "readme-template", "",
+		"text/template file to render README.md from instead of the built-in template")
+	fs.StringVar(&opts.OutputDir, "output-dir", "",
+		"Copy the template into this new directory and initialize it there instead of in place")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if opts.GoVersion != "" && !isValidGoVersion(opts.GoVersion) {
+		return nil, fmt.Errorf("invalid --go-version %q: expected a version like 1.23 or 1.23.4", opts.GoVersion)
 	}
+
+	if opts.GitTimeout <= 0 {
+		return nil, fmt.Errorf("invalid --git-timeout %s: must be positive", opts.GitTimeout)
+	}
+
+	if opts.ReadmeTemplate != "" {
+		tmpl, err := parseReadmeTemplate(opts.ReadmeTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --readme-template %s: %w", opts.ReadmeTemplate, err)
+		}
+		opts.readmeTemplate = tmpl
+	}
+
+	if opts.OutputDir != "" {
+		if err := checkOutputDir(opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("invalid --output-dir %s: %w", opts.OutputDir, err)
+		}
+	}
+
+	return opts, nil
 }
 
-func gatherProjectInfo() (*ProjectConfig, error) {
-	reader := bufio.NewReader(os.Stdin)
+func gatherProjectInfo(reader *bufio.Reader, opts *initOptions) (*ProjectConfig, error) {
 	config := &ProjectConfig{}
 
 	// Get current directory name as default project name
@@ -85,21 +296,23 @@ func gatherProjectInfo() (*ProjectConfig, error) {
 	defaultProjectName := filepath.Base(cwd)
 
 	// Project name
-	config.ProjectName = promptWithDefault(reader, "Project name", defaultProjectName)
-	if !isValidProjectName(config.ProjectName) {
-		return nil, fmt.Errorf("invalid project name: must contain only letters, numbers, and hyphens")
+	config.ProjectName, err = promptValid(reader, "Project name", defaultProjectName, validateProjectName)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project name %w", err)
 	}
 
+	// Git remote (optional), asked early so it can drive the module path default
+	config.GitRemote = prompt(reader, "Git remote URL (optional)")
+
 	// Module path
-	defaultModulePath := fmt.Sprintf("github.com/your-org/%s", config.ProjectName)
-	config.ModulePath = promptWithDefault(reader, "Go module path", defaultModulePath)
-	if !isValidModulePath(config.ModulePath) {
-		return nil, fmt.Errorf("invalid module path format")
+	defaultModulePath := defaultModulePathFor(config.GitRemote, config.ProjectName)
+	config.ModulePath, err = promptValid(reader, "Go module path", defaultModulePath, validateModulePath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid module path %w", err)
 	}
 
 	// Description
-	config.Description = promptWithDefault(reader, "Project description",
-		"A Go application built from go-template-project")
+	config.Description = promptWithDefault(reader, "Project description", defaultDescription)
 
 	// Try to get git config for defaults
 	gitAuthor := getGitConfig("user.name", defaultAuthor)
@@ -109,117 +322,595 @@ func gatherProjectInfo() (*ProjectConfig, error) {
 	config.Email = promptWithDefault(reader, "Author email", gitEmail)
 	config.License = promptWithDefault(reader, "License", defaultLicense)
 
+	// Go version for go.mod, defaulting to the installed toolchain
+	goVersion := opts.GoVersion
+	if goVersion == "" {
+		goVersion = detectGoVersion()
+	}
+	config.GoVersion, err = promptValid(reader, "Go version", goVersion, validateGoVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Go version %w", err)
+	}
+
 	// Components to enable
-	fmt.Println("\nComponents to include:")
+	fmt.Fprintln(out, "\nComponents to include:")
 	config.EnableCLI = promptBool(reader, "Include CLI application", true)
 	config.EnableServer = promptBool(reader, "Include HTTP server", true)
 	config.EnableWorker = promptBool(reader, "Include background worker", false)
+	config.EnableScheduler = promptBool(reader, "Include scheduled jobs (cron)", false)
+	config.EnableGRPC = promptBool(reader, "Include gRPC service", opts.GRPC)
+	config.EnableDatabase = promptBool(reader, "Include database layer", false)
 	config.EnableDocs = promptBool(reader, "Include documentation setup", true)
-	config.EnableE2ETests = promptBool(reader, "Include E2E tests", false)
 
-	// Git remote (optional)
-	config.GitRemote = prompt(reader, "Git remote URL (optional)")
+	fmt.Fprintln(out, "\nTest tiers (unit tests are always included):")
+	config.EnableIntegrationTests = promptBool(reader, "Include integration tests", true)
+	config.EnableSmokeTests = promptBool(reader, "Include smoke tests", true)
+	config.EnableE2ETests = promptBool(reader, "Include E2E tests", false)
 
 	// Confirmation
-	fmt.Println("\n📋 Configuration Summary:")
-	fmt.Printf("  Project Name: %s\n", config.ProjectName)
-	fmt.Printf("  Module Path:  %s\n", config.ModulePath)
-	fmt.Printf("  Description:  %s\n", config.Description)
-	fmt.Printf("  Author:       %s <%s>\n", config.Author, config.Email)
-	fmt.Printf("  License:      %s\n", config.License)
-	fmt.Printf("  Components:   CLI=%t Server=%t Worker=%t Docs=%t E2E=%t\n",
-		config.EnableCLI, config.EnableServer, config.EnableWorker, config.EnableDocs, config.EnableE2ETests)
+	printSummary(config)
 
 	if !promptBool(reader, "\nProceed with initialization?", false) {
-		fmt.Println("❌ Initialization cancelled")
+		fmt.Fprintln(out, "❌ Initialization cancelled")
 		os.Exit(0)
 	}
 
 	return config, nil
 }
 
-func initializeProject(config *ProjectConfig) error {
-	// Update go.mod
-	if err := updateGoMod(config); err != nil {
-		return fmt.Errorf("failed to update go.mod: %w", err)
+// printSummary shows the configuration about to be applied.
+func printSummary(config *ProjectConfig) {
+	fmt.Fprintln(out, "\n📋 Configuration Summary:")
+	fmt.Fprintf(out, "  Project Name: %s\n", config.ProjectName)
+	fmt.Fprintf(out, "  Module Path:  %s\n", config.ModulePath)
+	fmt.Fprintf(out, "  Description:  %s\n", config.Description)
+	fmt.Fprintf(out, "  Author:       %s <%s>\n", config.Author, config.Email)
+	fmt.Fprintf(out, "  License:      %s\n", config.License)
+	fmt.Fprintf(out, "  Go Version:   %s\n", config.GoVersion)
+	fmt.Fprintf(out, "  Components:   CLI=%t Server=%t Worker=%t Scheduler=%t gRPC=%t Database=%t Docs=%t\n",
+		config.EnableCLI, config.EnableServer, config.EnableWorker, config.EnableScheduler, config.EnableGRPC,
+		config.EnableDatabase, config.EnableDocs)
+	fmt.Fprintf(out, "  Test Tiers:   Unit=true Integration=%t Smoke=%t E2E=%t\n",
+		config.EnableIntegrationTests, config.EnableSmokeTests, config.EnableE2ETests)
+}
+
+// loadAnswers reads a JSON answers file keyed by the ProjectConfig json tags.
+// Keys left out take the same defaults as the interactive prompts. Unknown
+// keys and values of the wrong type are rejected rather than ignored.
+func loadAnswers(path string, opts *initOptions) (*ProjectConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read answers file: %w", err)
 	}
 
-	// Update import paths in all Go files
-	if err := updateImportPaths(config); err != nil {
-		return fmt.Errorf("failed to update import paths: %w", err)
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current directory: %w", err)
 	}
 
-	// Remove unwanted components
-	if err := removeUnwantedComponents(config); err != nil {
-		return fmt.Errorf("failed to remove unwanted components: %w", err)
+	goVersion := opts.GoVersion
+	if goVersion == "" {
+		goVersion = detectGoVersion()
 	}
 
-	// Clean up template artifacts
-	if err := cleanupTemplateArtifacts(config); err != nil {
-		return fmt.Errorf("failed to clean up template artifacts: %w", err)
+	config := &ProjectConfig{
+		ProjectName:  filepath.Base(cwd),
+		Description:  defaultDescription,
+		Author:       getGitConfig("user.name", defaultAuthor),
+		Email:        getGitConfig("user.email", defaultEmail),
+		License:      defaultLicense,
+		GoVersion:    goVersion,
+		EnableCLI:    true,
+		EnableServer: true,
+		EnableGRPC:   opts.GRPC,
+		EnableDocs:   true,
+
+		EnableIntegrationTests: true,
+		EnableSmokeTests:       true,
 	}
 
-	// Generate README
-	if err := generateReadme(config); err != nil {
-		return fmt.Errorf("failed to generate README: %w", err)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(config); err != nil {
+		return nil, answersError(path, err)
+	}
+
+	if config.ModulePath == "" {
+		config.ModulePath = defaultModulePathFor(config.GitRemote, config.ProjectName)
+	}
+
+	if err := validateProjectName(config.ProjectName); err != nil {
+		return nil, fmt.Errorf("invalid answers file %s: project_name %q: %w", path, config.ProjectName, err)
+	}
+	if err := validateModulePath(config.ModulePath); err != nil {
+		return nil, fmt.Errorf("invalid answers file %s: module_path %q: %w", path, config.ModulePath, err)
+	}
+	if err := validateGoVersion(config.GoVersion); err != nil {
+		return nil, fmt.Errorf("invalid answers file %s: go_version %q: %w", path, config.GoVersion, err)
+	}
+
+	return config, nil
+}
+
+// answersError rewrites JSON decoding errors to name the offending key.
+func answersError(path string, err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return fmt.Errorf("invalid answers file %s: %q must be a %s, got %s",
+			path, typeErr.Field, typeErr.Type, typeErr.Value)
+	}
+
+	if key, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return fmt.Errorf("invalid answers file %s: unknown key %s (valid keys: %s)",
+			path, key, strings.Join(answerKeys(), ", "))
 	}
 
-	// Initialize git repository (skip in test environments to prevent hanging)
-	if os.Getenv("SKIP_GIT_INIT") == "" {
-		if err := initializeGit(config); err != nil {
-			fmt.Printf("⚠️  Failed to initialize git: %v\n", err)
-			fmt.Println("   Continuing without git initialization...")
+	return fmt.Errorf("invalid answers file %s: %w", path, err)
+}
+
+// answerKeys lists the keys accepted in an answers file.
+func answerKeys() []string {
+	t := reflect.TypeOf(ProjectConfig{})
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		keys = append(keys, t.Field(i).Tag.Get("json"))
+	}
+	return keys
+}
+
+func initializeProject(config *ProjectConfig, opts *initOptions) error {
+	// Work on a copy when asked, so the checkout stays a pristine template
+	if opts.OutputDir != "" {
+		if err := step("copyTemplate", func() error { return enterOutputDir(opts.OutputDir) }); err != nil {
+			return fmt.Errorf("failed to copy template to %s: %w", opts.OutputDir, err)
 		}
-	} else {
-		fmt.Println("ℹ️  Skipping git initialization (test environment)")
+	}
+
+	// Refuse to run on a project that has already been transformed
+	if err := ensureNotInitialized(); err != nil {
+		return err
+	}
+	if err := ensurePristine(opts.Force); err != nil {
+		return err
+	}
+
+	// Rewrite files first, backing up originals so a failure can be undone.
+	// Destructive removals are deferred until every rewrite has succeeded.
+	rb := newRollback()
+	if err := applyRewrites(config, opts, rb); err != nil {
+		return restoreAfterFailure(rb, err)
+	}
+
+	// Seed governance docs for the new project's maintainers
+	if opts.Governance {
+		if err := generateGovernanceDocs(config, rb); err != nil {
+			return restoreAfterFailure(rb, fmt.Errorf("failed to generate governance docs: %w", err))
+		}
+	}
+
+	// Remove unwanted components
+	if err := step("removeUnwantedComponents", func() error {
+		_, err := removeUnwantedComponents(config)
+		return err
+	}); err != nil {
+		return restoreAfterFailure(rb, fmt.Errorf("failed to remove unwanted components: %w", err))
+	}
+
+	// Clean up template artifacts
+	if _, err := cleanupTemplateArtifacts(config); err != nil {
+		return restoreAfterFailure(rb, fmt.Errorf("failed to clean up template artifacts: %w", err))
+	}
+
+	// Initialize git repository unless asked not to
+	if opts.SkipGit {
+		fmt.Fprintln(out, "ℹ️  Skipping git init and initial commit (--skip-git)")
+	} else if err := step("initializeGit", func() error { return initializeGit(config, opts.GitTimeout) }); err != nil {
+		fmt.Fprintf(out, "⚠️  Failed to initialize git: %v\n", err)
+		fmt.Fprintln(out, "   Continuing without git initialization...")
 	}
 
 	// Install pre-commit hooks
-	if err := setupPreCommitHooks(); err != nil {
-		fmt.Printf("⚠️  Failed to setup pre-commit hooks: %v\n", err)
-		fmt.Println("   You can set them up later with: pre-commit install")
+	if err := step("setupPreCommitHooks", setupPreCommitHooks); err != nil {
+		fmt.Fprintf(out, "⚠️  Failed to setup pre-commit hooks: %v\n", err)
+		fmt.Fprintln(out, "   You can set them up later with: pre-commit install")
 	}
 
-	// Final cleanup: Remove the init script itself
-	fmt.Println("🗑️  Removing initialization script...")
-	if err := os.Remove("scripts/init.go"); err != nil {
-		fmt.Printf("⚠️  Failed to remove init script: %v\n", err)
-		fmt.Println("   You can remove it manually: rm scripts/init.go")
+	if opts.KeepInit {
+		fmt.Fprintln(out, "ℹ️  Keeping initialization script (--keep-init)")
+		return nil
+	}
+
+	// Final cleanup: Remove the init script itself and its tests
+	fmt.Fprintln(out, "🗑️  Removing initialization script...")
+	for _, file := range []string{"scripts/init.go", "scripts/init_test.go"} {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			fmt.Fprintf(out, "⚠️  Failed to remove %s: %v\n", file, err)
+			fmt.Fprintf(out, "   You can remove it manually: rm %s\n", file)
+		}
 	}
 
 	// Remove scripts directory if it's now empty
 	if err := removeEmptyDirectory("scripts"); err != nil {
 		// Non-critical, just log
-		fmt.Printf("ℹ️  Could not remove scripts directory: %v\n", err)
+		fmt.Fprintf(out, "ℹ️  Could not remove scripts directory: %v\n", err)
+	}
+
+	return nil
+}
+
+// applyRewrites performs every in-place file rewrite, recording the original
+// contents in rb before each file is touched.
+func applyRewrites(config *ProjectConfig, opts *initOptions, rb *rollback) error {
+	// Update go.mod
+	if err := step("updateGoMod", func() error { return updateGoMod(config, rb) }); err != nil {
+		return fmt.Errorf("failed to update go.mod: %w", err)
+	}
+
+	// Update import paths in all Go files
+	if err := step("updateImportPaths", func() error { return updateImportPaths(config, rb) }); err != nil {
+		return fmt.Errorf("failed to update import paths: %w", err)
+	}
+
+	// Remove template references from documentation
+	if config.EnableDocs {
+		if err := cleanupDocumentationReferences(config, rb); err != nil {
+			return fmt.Errorf("failed to cleanup documentation: %w", err)
+		}
+	}
+
+	// Generate README
+	if err := step("generateReadme", func() error { return generateReadme(config, opts.readmeTemplate, rb) }); err != nil {
+		return fmt.Errorf("failed to generate README: %w", err)
+	}
+
+	// Drop Makefile targets for components that won't exist
+	if err := generateMakefile(config, rb); err != nil {
+		return fmt.Errorf("failed to generate Makefile: %w", err)
+	}
+
+	// Build and expose only the binaries that were kept
+	if err := step("generateDockerfile", func() error { return generateDockerfile(config, rb) }); err != nil {
+		return fmt.Errorf("failed to generate Dockerfile: %w", err)
+	}
+
+	// Drop CI steps for test tiers that won't exist
+	if err := generateCIWorkflow(config, rb); err != nil {
+		return fmt.Errorf("failed to update CI workflow: %w", err)
+	}
+
+	return nil
+}
+
+// step runs fn and, in verbose mode, reports when it starts and how long it
+// took, so a stalled init shows which step it is stuck in.
+func step(name string, fn func() error) error {
+	if !verbose {
+		return fn()
+	}
+
+	fmt.Fprintf(out, "⏱️  %s: started\n", name)
+	start := time.Now()
+	err := fn()
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		fmt.Fprintf(out, "⏱️  %s: failed after %s\n", name, elapsed)
+	} else {
+		fmt.Fprintf(out, "⏱️  %s: finished in %s\n", name, elapsed)
+	}
+	return err
+}
+
+// restoreAfterFailure rolls back rewritten files and returns the original error,
+// annotated if the restore itself failed.
+func restoreAfterFailure(rb *rollback, err error) error {
+	fmt.Fprintln(out, "↩️  Initialization failed, restoring original files...")
+	if restoreErr := rb.restore(); restoreErr != nil {
+		return fmt.Errorf("%w (restore also failed: %v)", err, restoreErr)
+	}
+	fmt.Fprintln(out, "   ✅ Original files restored")
+	return err
+}
+
+// fileBackup is the original state of a file before init rewrote it.
+type fileBackup struct {
+	content []byte
+	mode    os.FileMode
+	existed bool
+}
+
+// rollback snapshots files before they are rewritten so a failed init can
+// put the working directory back the way it found it.
+type rollback struct {
+	backups map[string]fileBackup
+	order   []string
+}
+
+func newRollback() *rollback {
+	return &rollback{backups: make(map[string]fileBackup)}
+}
+
+// backup records the current state of path. Only the first call per path is
+// kept, so the snapshot always reflects the pre-init contents.
+func (r *rollback) backup(path string) error {
+	if _, ok := r.backups[path]; ok {
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		r.backups[path] = fileBackup{existed: false}
+		r.order = append(r.order, path)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	r.backups[path] = fileBackup{content: content, mode: info.Mode(), existed: true}
+	r.order = append(r.order, path)
+	return nil
+}
+
+// restore writes every backed-up file back, removing files that did not
+// exist before init created them.
+func (r *rollback) restore() error {
+	var failed []string
+	for i := len(r.order) - 1; i >= 0; i-- {
+		path := r.order[i]
+		b := r.backups[path]
+
+		var err error
+		if b.existed {
+			err = os.WriteFile(path, b.content, b.mode)
+		} else {
+			err = os.Remove(path)
+			if os.IsNotExist(err) {
+				err = nil
+			}
+		}
+
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", path, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to restore %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// checkOutputDir returns an error unless dir is missing or an empty
+// directory, so --output-dir never mixes the template into existing files.
+func checkOutputDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("directory is not empty")
+	}
+	return nil
+}
+
+// enterOutputDir copies the template in the current directory to dir and
+// makes dir the working directory, so every later step, including git init,
+// applies to the copy.
+func enterOutputDir(dir string) error {
+	if err := checkOutputDir(dir); err != nil {
+		return err
+	}
+	if err := copyTemplate(".", dir); err != nil {
+		return err
+	}
+	if err := os.Chdir(dir); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "ℹ️  Copied template to %s\n", dir)
+	return nil
+}
+
+// copyTemplate copies the template tree at src into dst, preserving file
+// modes and symlinks. The .git directory is left behind so the copy starts
+// its own history, and dst itself is skipped when it lies inside src.
+func copyTemplate(src, dst string) error {
+	if err := os.MkdirAll(dst, 0o755); err != nil {
+		return err
+	}
+	absDst, err := filepath.Abs(dst)
+	if err != nil {
+		return err
+	}
+
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		if d.IsDir() {
+			if abs, err := filepath.Abs(path); err == nil && abs == absDst {
+				return filepath.SkipDir
+			}
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case info.Mode().IsRegular():
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(target, data, info.Mode().Perm())
+		default:
+			// Sockets, devices and the like aren't part of a template
+			return nil
+		}
+	})
+}
+
+// ensureNotInitialized returns an error when go.mod no longer declares the
+// template module path, meaning init has already run (fully or partially).
+// Re-running would rewrite files that have already been customized.
+func ensureNotInitialized() error {
+	modulePath, err := readModulePath("go.mod")
+	if err != nil {
+		return fmt.Errorf("failed to read go.mod: %w", err)
+	}
+
+	if modulePath != templateModulePath {
+		return fmt.Errorf("project already initialized (module path is %q, expected %q); "+
+			"re-run init from a fresh template checkout", modulePath, templateModulePath)
 	}
 
 	return nil
 }
 
-func updateGoMod(config *ProjectConfig) error {
+// templateReadmeTitle is the first line of the template's own README.
+const templateReadmeTitle = "# go-template-project"
+
+// ensurePristine returns an error, after printing what it found, when the
+// directory has local changes init could clobber: uncommitted git changes
+// or a README that is no longer the template's. force skips the check.
+func ensurePristine(force bool) error {
+	if force {
+		return nil
+	}
+
+	findings := detectLocalChanges()
+	if len(findings) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(out, "⚠️  This directory doesn't look like a clean template checkout:")
+	for _, finding := range findings {
+		fmt.Fprintf(out, "   - %s\n", finding)
+	}
+	return fmt.Errorf("directory has local changes; commit or discard them, or re-run with --force")
+}
+
+// detectLocalChanges describes local modifications that init would overwrite.
+func detectLocalChanges() []string {
+	var findings []string
+
+	if content, err := os.ReadFile("README.md"); err == nil {
+		title, _, _ := strings.Cut(string(content), "\n")
+		if strings.TrimSpace(title) != templateReadmeTitle {
+			findings = append(findings, "README.md is not the template README")
+		}
+	}
+
+	// Only consult git when this is a checkout; init copies have no history
+	if _, err := os.Stat(".git"); err == nil {
+		status, err := exec.Command("git", "status", "--porcelain").Output()
+		if err == nil {
+			for _, line := range strings.Split(strings.TrimRight(string(status), "\n"), "\n") {
+				if line != "" {
+					findings = append(findings, "uncommitted change: "+strings.TrimSpace(line))
+				}
+			}
+		}
+	}
+
+	return findings
+}
+
+// readModulePath returns the module path declared in the given go.mod file.
+func readModulePath(goModPath string) (string, error) {
+	content, err := os.ReadFile(goModPath)
+	if err != nil {
+		return "", err
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "module ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "module ")), nil
+		}
+	}
+
+	return "", fmt.Errorf("no module directive found in %s", goModPath)
+}
+
+func updateGoMod(config *ProjectConfig, rb *rollback) error {
 	goModContent := fmt.Sprintf(`module %s
 
-go 1.23
+go %s
 
 require (
 	// Runtime dependencies will be added as needed
 )
-`, config.ModulePath)
+`, config.ModulePath, config.GoVersion)
+
+	if err := rb.backup("go.mod"); err != nil {
+		return err
+	}
 
 	return os.WriteFile("go.mod", []byte(goModContent), 0o644)
 }
 
-func updateImportPaths(config *ProjectConfig) error {
-	oldPath := "github.com/your-org/go-template-project"
+// skipImportRewriteDirs are directory names updateImportPaths never descends into.
+var skipImportRewriteDirs = map[string]bool{
+	"vendor":   true,
+	".git":     true,
+	"testdata": true,
+}
+
+func updateImportPaths(config *ProjectConfig, rb *rollback) error {
+	oldPath := templateModulePath
 	newPath := config.ModulePath
 
+	// Nothing to rewrite if the module path is unchanged
+	if oldPath == newPath {
+		return nil
+	}
+
 	return filepath.Walk(".", func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
-		// Skip directories and non-Go files
-		if info.IsDir() || !strings.HasSuffix(path, ".go") {
+		// Vendored code, git internals and test fixtures must stay byte-for-byte
+		if info.IsDir() {
+			if skipImportRewriteDirs[info.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		// Skip files that can't contain import paths
+		if !(strings.HasSuffix(path, ".go") || strings.HasSuffix(path, ".proto")) {
 			return nil
 		}
 
@@ -229,115 +920,201 @@ func updateImportPaths(config *ProjectConfig) error {
 			return err
 		}
 
-		// Replace import paths
-		newContent := strings.ReplaceAll(string(content), oldPath, newPath)
+		// Leave files that don't reference the template path untouched
+		if !strings.Contains(string(content), oldPath) {
+			return nil
+		}
 
-		// Write back if changed
-		if newContent != string(content) {
-			return os.WriteFile(path, []byte(newContent), info.Mode())
+		if err := rb.backup(path); err != nil {
+			return err
 		}
 
-		return nil
+		// Replace import paths
+		newContent := strings.ReplaceAll(string(content), oldPath, newPath)
+		return os.WriteFile(path, []byte(newContent), info.Mode())
 	})
 }
 
-func removeUnwantedComponents(config *ProjectConfig) error {
-	// Remove CLI if not wanted
-	if !config.EnableCLI {
-		if err := os.RemoveAll("cmd/cli"); err != nil {
-			return err
-		}
+// removeUnwantedComponents deletes the directories of every disabled
+// component, logging each one, and returns the paths it removed. Paths that
+// are already absent are skipped. It runs only after every rewrite has
+// succeeded, since removals can't be rolled back.
+func removeUnwantedComponents(config *ProjectConfig) ([]string, error) {
+	components := []struct {
+		enabled bool
+		paths   []string
+	}{
+		{config.EnableCLI, []string{"cmd/cli"}},
+		{config.EnableServer, []string{"cmd/server"}},
+		{config.EnableWorker, []string{"cmd/worker"}},
+		// The worker's health and metrics endpoints reuse the HTTP handlers
+		{config.EnableServer || config.EnableWorker, []string{"internal/handlers"}},
+		// Scheduler and its cron primitives
+		{config.EnableScheduler, []string{"cmd/scheduler", "internal/cron"}},
+		// gRPC service and its proto definitions
+		{config.EnableGRPC, []string{"cmd/grpc", "proto"}},
+		// Database layer and migrations
+		{config.EnableDatabase, []string{"internal/store", "migrations"}},
+		{config.EnableDocs, []string{"docs"}},
+		// Test tiers kept in their own directories
+		{config.EnableSmokeTests, []string{"tests/smoke"}},
+		{config.EnableE2ETests, []string{"tests/e2e"}},
 	}
 
-	// Remove server if not wanted
-	if !config.EnableServer {
-		if err := os.RemoveAll("cmd/server"); err != nil {
-			return err
-		}
-		if err := os.RemoveAll("internal/handlers"); err != nil {
-			return err
+	var removed []string
+	for _, component := range components {
+		if component.enabled {
+			continue
 		}
-	}
+		for _, path := range component.paths {
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				continue
+			} else if err != nil {
+				return removed, err
+			}
 
-	// Remove worker if not wanted
-	if !config.EnableWorker {
-		if err := os.RemoveAll("cmd/worker"); err != nil {
-			return err
+			fmt.Fprintf(out, "   🗑️  Removing %s/\n", path)
+			if err := os.RemoveAll(path); err != nil {
+				return removed, err
+			}
+			removed = append(removed, path)
 		}
 	}
 
-	// Remove docs setup if not wanted
-	if !config.EnableDocs {
-		if err := os.RemoveAll("docs"); err != nil {
-			return err
-		}
-	}
+	return removed, nil
+}
 
-	// Remove E2E tests if not wanted
-	if !config.EnableE2ETests {
-		if err := os.RemoveAll("tests"); err != nil {
-			return err
-		}
-	}
+// cleanupSummary reports what cleanupTemplateArtifacts did with each
+// template file it considered. Files that were already absent are in
+// neither list.
+type cleanupSummary struct {
+	Removed []string
+	Kept    []string
+}
 
-	return nil
+// templateArtifact is a file cleanupTemplateArtifacts may remove, with the
+// reason it would be removed.
+type templateArtifact struct {
+	path   string
+	remove bool
+	reason string
 }
 
-func cleanupTemplateArtifacts(config *ProjectConfig) error {
-	fmt.Println("🧹 Cleaning up template artifacts...")
+// cleanupTemplateArtifacts removes template-only files and the tests of
+// disabled components, returning which files were removed and kept. Errors
+// name the file and why it was being removed.
+func cleanupTemplateArtifacts(config *ProjectConfig) (*cleanupSummary, error) {
+	fmt.Fprintln(out, "🧹 Cleaning up template artifacts...")
 
-	// Always remove template-specific files
-	templateFiles := []string{
-		"tests/e2e/init_e2e_test.go", // Tests the init script itself
+	artifacts := []templateArtifact{
+		// Tests the init script itself
+		{"tests/e2e/init_e2e_test.go", true, "template-only test"},
 	}
 
-	for _, file := range templateFiles {
-		if err := removeFileIfExists(file); err != nil {
-			return fmt.Errorf("failed to remove %s: %w", file, err)
-		}
+	// Integration tests live beside the code they exercise
+	integrationTests, err := findIntegrationTests()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find integration tests: %w", err)
+	}
+	for _, path := range integrationTests {
+		artifacts = append(artifacts, templateArtifact{path, !config.EnableIntegrationTests, "integration tests disabled"})
 	}
 
-	// Remove component-specific E2E tests based on selection
+	// Smoke tests for components that won't exist; without smoke tests
+	// tests/smoke is gone
+	if config.EnableSmokeTests {
+		artifacts = append(artifacts,
+			templateArtifact{"tests/smoke/cli_smoke_test.go", !config.EnableCLI, "CLI disabled"},
+			templateArtifact{"tests/smoke/server_smoke_test.go", !config.EnableServer, "server disabled"},
+		)
+	}
+
+	// Component-specific E2E tests; without E2E tests tests/e2e is gone
 	if config.EnableE2ETests {
-		if !config.EnableCLI {
-			if err := removeFileIfExists("tests/e2e/cli_e2e_test.go"); err != nil {
-				return err
+		artifacts = append(artifacts,
+			templateArtifact{"tests/e2e/cli_e2e_test.go", !config.EnableCLI, "CLI disabled"},
+			templateArtifact{"tests/e2e/server_e2e_test.go", !config.EnableServer, "server disabled"},
+			templateArtifact{"tests/e2e/worker_e2e_test.go", !config.EnableWorker, "worker disabled"},
+			templateArtifact{"tests/e2e/database_e2e_test.go", !config.EnableDatabase, "database disabled"},
+		)
+	}
+
+	summary := &cleanupSummary{}
+	for _, artifact := range artifacts {
+		if !artifact.remove {
+			if exists, err := fileExists(artifact.path); err != nil {
+				return summary, fmt.Errorf("failed to check %s: %w", artifact.path, err)
+			} else if exists {
+				summary.Kept = append(summary.Kept, artifact.path)
 			}
+			continue
 		}
-		if !config.EnableServer {
-			if err := removeFileIfExists("tests/e2e/server_e2e_test.go"); err != nil {
-				return err
-			}
+
+		removed, err := removeFileIfExists(artifact.path)
+		if err != nil {
+			return summary, fmt.Errorf("failed to remove %s (%s): %w", artifact.path, artifact.reason, err)
 		}
-		if !config.EnableWorker {
-			if err := removeFileIfExists("tests/e2e/worker_e2e_test.go"); err != nil {
-				return err
-			}
+		if removed {
+			summary.Removed = append(summary.Removed, artifact.path)
 		}
 	}
 
-	// Remove template references from documentation
-	if config.EnableDocs {
-		if err := cleanupDocumentationReferences(config); err != nil {
-			return fmt.Errorf("failed to cleanup documentation: %w", err)
+	// Drop tests/ once no tier that lives there is left
+	if !config.EnableSmokeTests && !config.EnableE2ETests {
+		if err := removeEmptyDirectory("tests"); err != nil {
+			fmt.Fprintf(out, "   ℹ️  Keeping tests/: %v\n", err)
 		}
 	}
 
-	// Final step: Schedule init script for removal (will remove itself at the end)
-	// We can't remove it now since we're running from it
-	fmt.Println("   ✅ Scheduled init script for removal")
+	// The init script itself is removed last, once git setup has run
+	fmt.Fprintf(out, "   ✅ Removed %d template file(s), kept %d\n", len(summary.Removed), len(summary.Kept))
 
-	return nil
+	return summary, nil
 }
 
-func removeFileIfExists(filepath string) error {
-	if _, err := os.Stat(filepath); err == nil {
-		fmt.Printf("   🗑️  Removing %s\n", filepath)
-		return os.Remove(filepath)
-	} else if !os.IsNotExist(err) {
-		return err
+// findIntegrationTests returns the *integration_test.go files outside tests/,
+// where the other tiers live, in walk order.
+func findIntegrationTests() ([]string, error) {
+	var paths []string
+	err := filepath.Walk(".", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path == "tests" || skipImportRewriteDirs[info.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(path, "integration_test.go") {
+			paths = append(paths, filepath.ToSlash(path))
+		}
+		return nil
+	})
+	return paths, err
+}
+
+// removeFileIfExists removes path, reporting whether it existed.
+func removeFileIfExists(path string) (bool, error) {
+	if exists, err := fileExists(path); err != nil || !exists {
+		return false, err
 	}
-	return nil
+
+	fmt.Fprintf(out, "   🗑️  Removing %s\n", path)
+	if err := os.Remove(path); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// fileExists reports whether path exists; errors other than not-existing
+// are returned.
+func fileExists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
 }
 
 func removeEmptyDirectory(dirpath string) error {
@@ -350,7 +1127,7 @@ func removeEmptyDirectory(dirpath string) error {
 	return os.Remove(dirpath)
 }
 
-func cleanupDocumentationReferences(config *ProjectConfig) error {
+func cleanupDocumentationReferences(config *ProjectConfig, rb *rollback) error {
 	// Update Hugo documentation files to remove template references
 	docFiles := map[string]func(*ProjectConfig) string{
 		"docs/content/_index.md":               updateIndexMarkdown,
@@ -358,7 +1135,7 @@ func cleanupDocumentationReferences(config *ProjectConfig) error {
 	}
 
 	for file, updateFunc := range docFiles {
-		if err := updateDocumentationFile(file, updateFunc, config); err != nil {
+		if err := updateDocumentationFile(file, updateFunc, config, rb); err != nil {
 			return fmt.Errorf("failed to update %s: %w", file, err)
 		}
 	}
@@ -366,11 +1143,17 @@ func cleanupDocumentationReferences(config *ProjectConfig) error {
 	return nil
 }
 
-func updateDocumentationFile(filepath string, updateFunc func(*ProjectConfig) string, config *ProjectConfig) error {
+func updateDocumentationFile(
+	filepath string, updateFunc func(*ProjectConfig) string, config *ProjectConfig, rb *rollback,
+) error {
 	if _, err := os.Stat(filepath); os.IsNotExist(err) {
 		return nil // File doesn't exist, nothing to update
 	}
 
+	if err := rb.backup(filepath); err != nil {
+		return err
+	}
+
 	newContent := updateFunc(config)
 	return os.WriteFile(filepath, []byte(newContent), 0o644)
 }
@@ -400,7 +1183,7 @@ This project provides a production-ready Go application with:
 
 - **🏗️ Standard Project Layout** - Follows Go community best practices
 - **🔧 Complete Toolchain** - golangci-lint, gofumpt, comprehensive quality gates
-- **🧪 Progressive Testing** - Unit, integration, smoke%s tests
+- **🧪 Progressive Testing** - %s tests
 - **📦 Container Ready** - Multi-stage builds with distroless images (~10MB)
 - **🚀 CI/CD Ready** - GitHub Actions for testing, security, and releases%s
 - **⚡ Quality Gates** - Pre-commit hooks and comprehensive checks
@@ -614,6 +1397,15 @@ func generateComponentDescription(config *ProjectConfig) string {
 	if config.EnableWorker {
 		components = append(components, "**Background Worker** - Long-running process with signal handling")
 	}
+	if config.EnableScheduler {
+		components = append(components, "**Scheduler** - One-shot jobs run on cron schedules")
+	}
+	if config.EnableGRPC {
+		components = append(components, "**gRPC Service** - Protobuf service definitions with a server stub that closes connections until services are registered")
+	}
+	if config.EnableDatabase {
+		components = append(components, "**Database Layer** - database/sql store with SQL migrations")
+	}
 
 	if len(components) == 0 {
 		return "This project provides a foundation for building Go applications with clean architecture patterns."
@@ -641,6 +1433,12 @@ func generateRunCommands(config *ProjectConfig) string {
 	if config.EnableWorker {
 		commands = append(commands, "make run-worker   # Run background worker")
 	}
+	if config.EnableScheduler {
+		commands = append(commands, "make run-scheduler # Run scheduled jobs that are due")
+	}
+	if config.EnableGRPC {
+		commands = append(commands, "make run-grpc     # Run gRPC service stub")
+	}
 
 	if len(commands) == 0 {
 		return "go run ./..."
@@ -653,11 +1451,27 @@ func generateRunCommands(config *ProjectConfig) string {
 	return result
 }
 
+// generateTestingFeatures lists the enabled test tiers, e.g. "Unit, smoke,
+// and E2E".
 func generateTestingFeatures(config *ProjectConfig) string {
+	tiers := []string{"Unit"}
+	if config.EnableIntegrationTests {
+		tiers = append(tiers, "integration")
+	}
+	if config.EnableSmokeTests {
+		tiers = append(tiers, "smoke")
+	}
 	if config.EnableE2ETests {
-		return ", and E2E"
+		tiers = append(tiers, "E2E")
 	}
-	return ""
+
+	switch len(tiers) {
+	case 1:
+		return tiers[0]
+	case 2:
+		return tiers[0] + " and " + tiers[1]
+	}
+	return strings.Join(tiers[:len(tiers)-1], ", ") + ", and " + tiers[len(tiers)-1]
 }
 
 func generateDocumentationFeature(config *ProjectConfig) string {
@@ -668,7 +1482,22 @@ func generateDocumentationFeature(config *ProjectConfig) string {
 }
 
 func generateTestingSection(config *ProjectConfig) string {
-	if !config.EnableE2ETests {
+	tiers := []struct {
+		enabled bool
+		lines   string
+	}{
+		{config.EnableIntegrationTests, "# Integration tests (component interactions)\nmake test-integration\n\n"},
+		{config.EnableSmokeTests, "# Smoke tests (critical path validation)\nmake test-smoke\n\n"},
+		{config.EnableE2ETests, "# End-to-end tests (complete user journeys)\nmake test-e2e\n\n"},
+	}
+
+	commands := ""
+	for _, tier := range tiers {
+		if tier.enabled {
+			commands += tier.lines
+		}
+	}
+	if commands == "" {
 		return ""
 	}
 
@@ -682,23 +1511,16 @@ The project implements a progressive testing approach:
 # Unit tests (fast, isolated)
 make test-unit
 
-# Integration tests (component interactions)
-make test-integration
-
-# Smoke tests (critical path validation)
-make test-smoke
-
-# End-to-end tests (complete user journeys)
-make test-e2e
-
-# All tests
+` + commands + `# All tests
 make test-all
 ` + "```" + `
 
 `
 }
 
-func generateReadme(config *ProjectConfig) error {
+// generateReadme renders README.md from custom, or from the built-in
+// template when custom is nil.
+func generateReadme(config *ProjectConfig, custom *template.Template, rb *rollback) error {
 	readmeTemplate := `# {{.ProjectName}}
 
 > {{.Description}}
@@ -709,12 +1531,13 @@ starter template - providing quality gates, container deployment, and CI/CD with
 ## Quick Start
 
 ` + "```bash" + `
-git clone {{.GitRemote}}
+git clone {{.CloneURL}}
 cd {{.ProjectName}}
 make setup     # Install development tools
 make check     # Verify everything works
 {{if .EnableCLI}}go run ./cmd/cli{{end}}
 {{if .EnableServer}}go run ./cmd/server{{end}}
+{{if .EnableGRPC}}go run ./cmd/grpc{{end}}
 ` + "```" + `
 
 ## What You Get
@@ -744,6 +1567,9 @@ make check     # Verify everything works
 {{if .EnableCLI}}| CLI | ` + "`make run-cli`" + ` | Run command-line application |{{end}}
 {{if .EnableServer}}| Server | ` + "`make run-server`" + ` | Run HTTP server on :8080 |{{end}}
 {{if .EnableWorker}}| Worker | ` + "`make run-worker`" + ` | Run background worker |{{end}}
+{{if .EnableScheduler}}| Scheduler | ` + "`make run-scheduler`" + ` | Run scheduled jobs that are due now |{{end}}
+{{if .EnableGRPC}}| gRPC | ` + "`make run-grpc`" + ` | Run gRPC service stub on :9090 (register services to serve RPCs) |{{end}}
+{{if .EnableGRPC}}| Protobuf | ` + "`make proto`" + ` | Generate Go code from proto/ |{{end}}
 | All | ` + "`make build`" + ` | Build all binaries |
 | Quality | ` + "`make check`" + ` | Run all quality checks |
 {{if .EnableE2ETests}}| E2E Tests | ` + "`make test-e2e`" + ` | Run end-to-end tests |{{end}}
@@ -757,10 +1583,16 @@ make check     # Verify everything works
 {{if .EnableCLI}}│   ├── cli/                 # Command-line interface{{end}}
 {{if .EnableServer}}│   ├── server/             # HTTP server{{end}}
 {{if .EnableWorker}}│   └── worker/             # Background worker{{end}}
+{{if .EnableScheduler}}│   └── scheduler/          # Scheduled one-shot jobs{{end}}
+{{if .EnableGRPC}}│   └── grpc/               # gRPC service stub{{end}}
 ├── internal/                # Private application code
 │   ├── app/                 # Core business logic
 │   ├── config/              # Configuration management
 {{if .EnableServer}}│   └── handlers/           # HTTP handlers{{end}}
+{{if .EnableScheduler}}│   └── cron/               # Cron expression parsing{{end}}
+{{if .EnableDatabase}}│   └── store/              # Database access layer{{end}}
+{{if .EnableDatabase}}├── migrations/              # SQL schema migrations{{end}}
+{{if .EnableGRPC}}├── proto/                   # Protobuf service definitions{{end}}
 ├── .github/workflows/       # CI/CD pipelines
 ├── docker/                  # Container configuration
 ├── scripts/                 # Development scripts
@@ -783,7 +1615,7 @@ Configure via environment variables:
 {{if .EnableServer}}| ` + "`PORT`" + ` | ` + "`8080`" + ` | HTTP server port |{{end}}
 {{if .EnableServer}}| ` + "`HOST`" + ` | ` + "`0.0.0.0`" + ` | HTTP server host |{{end}}
 | ` + "`DEBUG`" + ` | ` + "`false`" + ` | Enable debug logging |
-| ` + "`DATABASE_URL`" + ` | | Database connection string |
+{{if .EnableDatabase}}| ` + "`DATABASE_URL`" + ` | | Database connection string |{{end}}
 
 {{if .EnableServer}}## API Endpoints
 
@@ -804,8 +1636,15 @@ Configure via environment variables:
 A batteries-included Go starter template.*
 `
 
-	tmpl, err := template.New("readme").Parse(readmeTemplate)
-	if err != nil {
+	tmpl := custom
+	if tmpl == nil {
+		var err error
+		if tmpl, err = template.New("readme").Parse(readmeTemplate); err != nil {
+			return err
+		}
+	}
+
+	if err := rb.backup("README.md"); err != nil {
 		return err
 	}
 
@@ -823,62 +1662,568 @@ A batteries-included Go starter template.*
 	return tmpl.Execute(file, data)
 }
 
-func initializeGit(config *ProjectConfig) error {
+// parseReadmeTemplate reads and parses a --readme-template file, then renders
+// it once against sample answers so mistakes such as unknown fields are
+// reported before init changes anything.
+func parseReadmeTemplate(path string) (*template.Template, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).Parse(string(content))
+	if err != nil {
+		return nil, err
+	}
+
+	sample := TemplateData{
+		ProjectConfig: ProjectConfig{ProjectName: "example", ModulePath: "github.com/your-org/example"},
+		Year:          "2024",
+	}
+	if err := tmpl.Execute(io.Discard, sample); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+const contributingTemplate = `# Contributing to {{.ProjectName}}
+
+Thanks for helping improve {{.ProjectName}}! This project is maintained by
+{{.Author}} <{{.Email}}>.
+
+## Getting Started
+
+1. Fork and clone the repository
+2. Run ` + "`make setup`" + ` to install development tools
+3. Create a feature branch for your change
+
+## Making Changes
+
+- Add or update tests alongside your code
+- Run ` + "`make check`" + ` before pushing; CI runs the same quality gates
+- Write commit messages in the Conventional Commits style (` + "`feat:`" + `, ` + "`fix:`" + `, ` + "`docs:`" + `)
+
+## Submitting a Pull Request
+
+Open a pull request describing what changed and why. Changes are reviewed by
+the code owners listed in ` + "`.github/CODEOWNERS`" + `.
+
+## Questions
+
+Open an issue or contact {{.Author}} at {{.Email}}.
+`
+
+const codeownersTemplate = `# Code owners are requested for review on every pull request.
+# See https://docs.github.com/en/repositories/managing-your-repositorys-settings-and-features/customizing-your-repository/about-code-owners
+
+# {{.Author}}
+* {{.Email}}
+`
+
+// generateGovernanceDocs writes CONTRIBUTING.md and .github/CODEOWNERS
+// naming the project author as maintainer and default code owner.
+func generateGovernanceDocs(config *ProjectConfig, rb *rollback) error {
+	files := []struct {
+		path     string
+		template string
+	}{
+		{"CONTRIBUTING.md", contributingTemplate},
+		{filepath.Join(".github", "CODEOWNERS"), codeownersTemplate},
+	}
+
+	for _, f := range files {
+		tmpl, err := template.New(f.path).Parse(f.template)
+		if err != nil {
+			return err
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, config); err != nil {
+			return fmt.Errorf("failed to render %s: %w", f.path, err)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+			return err
+		}
+		if err := rb.backup(f.path); err != nil {
+			return err
+		}
+		if err := os.WriteFile(f.path, buf.Bytes(), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.path, err)
+		}
+	}
+
+	return nil
+}
+
+// dockerfileTemplate renders a Dockerfile with one runtime stage per enabled
+// binary. It is executed with dockerfileData.
+const dockerfileTemplate = `# Build stage
+FROM golang:{{.GoVersion}}-alpine AS builder
+
+# Install build dependencies
+RUN apk add --no-cache git ca-certificates tzdata
+
+# Create non-root user for security
+RUN adduser -D -s /bin/sh -u 1001 appuser
+
+WORKDIR /app
+
+# Copy go mod files first for better caching
+COPY go.mod go.sum ./
+RUN go mod download
+
+# Copy source code
+COPY . .
+
+# Build the applications
+{{- range .Binaries}}
+RUN CGO_ENABLED=0 GOOS=linux go build \
+    -ldflags="-w -s -X main.appVersion=$(git describe --tags --always --dirty)" \
+    -a -installsuffix cgo \
+    -o /out/{{.}} ./cmd/{{.}}
+{{end}}
+{{- if .EnableCLI}}
+# ================================
+# CLI Runtime Image
+# ================================
+FROM gcr.io/distroless/static-debian12:nonroot AS cli
+
+COPY --from=builder /out/cli /usr/local/bin/cli
+ENTRYPOINT ["cli"]
+{{end}}
+{{- if .EnableServer}}
+# ================================
+# Server Runtime Image
+# ================================
+FROM gcr.io/distroless/static-debian12:nonroot AS server
+
+COPY --from=builder /out/server /usr/local/bin/server
+{{- if .EnableCLI}}
+COPY --from=builder /out/cli /usr/local/bin/cli
+{{- end}}
+EXPOSE 8080
+{{if .EnableCLI}}
+# Health check (the image has no shell or curl, so the CLI probes /health)
+HEALTHCHECK --interval=30s --timeout=3s --start-period=5s --retries=3 \
+    CMD ["/usr/local/bin/cli", "healthcheck", "--url", "http://localhost:8080/health"]
+{{else}}
+# No HEALTHCHECK: the image has no shell or curl and the CLI isn't built,
+# so probe /health from the orchestrator instead
+{{end}}
+ENTRYPOINT ["server"]
+{{end}}
+{{- if .EnableWorker}}
+# ================================
+# Worker Runtime Image
+# ================================
+FROM gcr.io/distroless/static-debian12:nonroot AS worker
+
+COPY --from=builder /out/worker /usr/local/bin/worker
+ENTRYPOINT ["worker"]
+{{end}}
+{{- if .EnableScheduler}}
+# ================================
+# Scheduler Runtime Image
+# ================================
+FROM gcr.io/distroless/static-debian12:nonroot AS scheduler
+
+# Runs the jobs that are due and exits; schedule it, e.g. as a CronJob
+COPY --from=builder /out/scheduler /usr/local/bin/scheduler
+ENTRYPOINT ["scheduler"]
+{{end}}
+{{- if .EnableGRPC}}
+# ================================
+# gRPC Runtime Image
+# (a stub that closes connections until services are registered)
+# ================================
+FROM gcr.io/distroless/static-debian12:nonroot AS grpc
+
+COPY --from=builder /out/grpc /usr/local/bin/grpc
+EXPOSE 9090
+ENTRYPOINT ["grpc"]
+{{end}}
+{{- with .Default}}
+# ================================
+# Default target ({{.}})
+# ================================
+FROM {{.}} AS default
+{{end}}`
+
+// dockerfileData is what dockerfileTemplate renders.
+type dockerfileData struct {
+	*ProjectConfig
+
+	// Binaries are the cmd/ directories built in the builder stage.
+	Binaries []string
+
+	// Default is the stage built when no --target is given; empty when no
+	// binary is enabled.
+	Default string
+}
+
+// generateDockerfile rewrites the Dockerfile so it only builds the enabled
+// binaries, with a runtime stage for each. Only the server and gRPC images
+// EXPOSE a port. The default target is the first enabled of server, gRPC,
+// worker, scheduler and CLI, so a service project runs its service by default.
+func generateDockerfile(config *ProjectConfig, rb *rollback) error {
+	if _, err := os.Stat("Dockerfile"); os.IsNotExist(err) {
+		return nil
+	}
+
+	data := dockerfileData{ProjectConfig: config}
+	for _, binary := range []struct {
+		name    string
+		enabled bool
+	}{
+		{"cli", config.EnableCLI},
+		{"server", config.EnableServer},
+		{"worker", config.EnableWorker},
+		{"scheduler", config.EnableScheduler},
+		{"grpc", config.EnableGRPC},
+	} {
+		if binary.enabled {
+			data.Binaries = append(data.Binaries, binary.name)
+		}
+	}
+	for _, candidate := range []struct {
+		name    string
+		enabled bool
+	}{
+		{"server", config.EnableServer},
+		{"grpc", config.EnableGRPC},
+		{"worker", config.EnableWorker},
+		{"scheduler", config.EnableScheduler},
+		{"cli", config.EnableCLI},
+	} {
+		if candidate.enabled {
+			data.Default = candidate.name
+			break
+		}
+	}
+
+	tmpl, err := template.New("Dockerfile").Parse(dockerfileTemplate)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("failed to render Dockerfile: %w", err)
+	}
+
+	if err := rb.backup("Dockerfile"); err != nil {
+		return err
+	}
+	return os.WriteFile("Dockerfile", buf.Bytes(), 0o644)
+}
+
+// generateMakefile rewrites the Makefile so build and run-* targets only
+// reference the components that were kept.
+func generateMakefile(config *ProjectConfig, rb *rollback) error {
+	content, err := os.ReadFile("Makefile")
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	components := []struct {
+		name    string
+		enabled bool
+		extra   []string // targets that only make sense with the component
+	}{
+		{"cli", config.EnableCLI, nil},
+		{"server", config.EnableServer, nil},
+		{"worker", config.EnableWorker, nil},
+		{"scheduler", config.EnableScheduler, nil},
+		{"grpc", config.EnableGRPC, []string{"proto"}},
+	}
+
+	var removedTargets, removedCmds []string
+	for _, component := range components {
+		if component.enabled {
+			continue
+		}
+		removedTargets = append(removedTargets, "run-"+component.name)
+		removedTargets = append(removedTargets, component.extra...)
+		removedCmds = append(removedCmds, "./cmd/"+component.name)
+	}
+	removedTargets = append(removedTargets, removedTestTargets(config)...)
+
+	if len(removedTargets) == 0 {
+		return nil
+	}
+
+	if err := rb.backup("Makefile"); err != nil {
+		return err
+	}
+
+	filtered := filterMakefile(string(content), removedTargets, removedCmds)
+	return os.WriteFile("Makefile", []byte(filtered), 0o644)
+}
+
+// removedTestTargets returns the Makefile targets of disabled test tiers.
+func removedTestTargets(config *ProjectConfig) []string {
+	tiers := []struct {
+		target  string
+		enabled bool
+	}{
+		{"test-integration", config.EnableIntegrationTests},
+		{"test-smoke", config.EnableSmokeTests},
+		{"test-e2e", config.EnableE2ETests},
+	}
+
+	var targets []string
+	for _, tier := range tiers {
+		if !tier.enabled {
+			targets = append(targets, tier.target)
+		}
+	}
+	return targets
+}
+
+// generateCIWorkflow drops the CI steps that run the Makefile targets of
+// disabled test tiers.
+func generateCIWorkflow(config *ProjectConfig, rb *rollback) error {
+	const path = ".github/workflows/ci.yml"
+
+	targets := removedTestTargets(config)
+	if len(targets) == 0 {
+		return nil
+	}
+
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	filtered := filterWorkflowSteps(string(content), targets)
+	if filtered == string(content) {
+		return nil
+	}
+
+	if err := rb.backup(path); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(filtered), 0o644)
+}
+
+// filterWorkflowSteps removes every workflow step whose run command is
+// "make <target>" for one of targets, along with the blank line after it.
+func filterWorkflowSteps(content string, targets []string) string {
+	isRun := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		isRun["run: make "+target] = true
+	}
+
+	lines := strings.Split(content, "\n")
+	var result []string
+	for i := 0; i < len(lines); {
+		trimmed := strings.TrimLeft(lines[i], " ")
+		if !strings.HasPrefix(trimmed, "- ") {
+			result = append(result, lines[i])
+			i++
+			continue
+		}
+
+		// A step runs until the next line indented no deeper than its dash
+		indent := len(lines[i]) - len(trimmed)
+		end := i + 1
+		for end < len(lines) {
+			next := strings.TrimLeft(lines[end], " ")
+			if next != "" && len(lines[end])-len(next) <= indent {
+				break
+			}
+			end++
+		}
+
+		step := lines[i:end]
+		i = end
+
+		removed := false
+		for _, line := range step {
+			if isRun[strings.TrimSpace(line)] {
+				removed = true
+				break
+			}
+		}
+		if !removed {
+			result = append(result, step...)
+			continue
+		}
+
+		// The blank lines after a removed last step still separate the
+		// list from what follows, unless the previous step kept its own
+		lastStep := i == len(lines) || !strings.HasPrefix(strings.TrimLeft(lines[i], " "), "- ")
+		if lastStep && len(result) > 0 && strings.TrimSpace(result[len(result)-1]) != "" {
+			for j := len(step) - 1; j > 0 && strings.TrimSpace(step[j]) == ""; j-- {
+				result = append(result, "")
+			}
+		}
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// filterMakefile removes the named targets (their rule line and recipe), drops
+// recipe lines that build any of the given cmd paths, and prunes the targets
+// from .PHONY declarations.
+func filterMakefile(content string, targets, cmdPaths []string) string {
+	isTarget := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		isTarget[target] = true
+	}
+
+	var result []string
+	skippingRecipe := false
+
+	for _, line := range strings.Split(content, "\n") {
+		// Recipe lines (tab-indented) belong to the rule being skipped
+		if skippingRecipe {
+			if strings.HasPrefix(line, "\t") {
+				continue
+			}
+			skippingRecipe = false
+			// Drop the blank line that separated the removed rule
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+		}
+
+		if name, _, ok := strings.Cut(line, ":"); ok && isTarget[name] {
+			skippingRecipe = true
+			continue
+		}
+
+		if strings.HasPrefix(line, ".PHONY:") {
+			fields := strings.Fields(strings.TrimPrefix(line, ".PHONY:"))
+			kept := fields[:0]
+			for _, field := range fields {
+				if !isTarget[field] {
+					kept = append(kept, field)
+				}
+			}
+			line = ".PHONY: " + strings.Join(kept, " ")
+		} else if name, rest, ok := strings.Cut(line, ":"); ok && !strings.HasPrefix(line, "#") && !strings.HasPrefix(rest, "=") {
+			// Drop removed targets from other rules' prerequisites
+			deps, comment, hasComment := strings.Cut(rest, "##")
+			fields := strings.Fields(deps)
+			kept := make([]string, 0, len(fields))
+			for _, field := range fields {
+				if !isTarget[field] {
+					kept = append(kept, field)
+				}
+			}
+			if len(kept) < len(fields) {
+				line = name + ":"
+				if len(kept) > 0 {
+					line += " " + strings.Join(kept, " ")
+				}
+				if hasComment {
+					line += " ##" + comment
+				}
+			}
+		}
+
+		if referencesAny(line, cmdPaths) {
+			continue
+		}
+
+		result = append(result, line)
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// referencesAny reports whether line mentions any of the cmd paths as a whole path.
+func referencesAny(line string, cmdPaths []string) bool {
+	for _, path := range cmdPaths {
+		for _, field := range strings.Fields(line) {
+			if field == path {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func initializeGit(config *ProjectConfig, timeout time.Duration) error {
 	// Initialize git repository
-	cmd := exec.Command("git", "init")
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to initialize git: %w (output: %s)", err, string(output))
+	if err := runGit(timeout, "init"); err != nil {
+		return fmt.Errorf("failed to initialize git: %w", err)
 	}
 
 	// Ensure git user config exists for commit (needed for E2E tests)
 	if err := exec.Command("git", "config", "user.name", config.Author).Run(); err != nil {
-		fmt.Printf("⚠️  Failed to set git user.name: %v\n", err)
+		fmt.Fprintf(out, "⚠️  Failed to set git user.name: %v\n", err)
 	}
 
 	if err := exec.Command("git", "config", "user.email", config.Email).Run(); err != nil {
-		fmt.Printf("⚠️  Failed to set git user.email: %v\n", err)
+		fmt.Fprintf(out, "⚠️  Failed to set git user.email: %v\n", err)
 	}
 
 	// Add git remote if provided
 	if config.GitRemote != "" {
 		if err := exec.Command("git", "remote", "add", "origin", config.GitRemote).Run(); err != nil {
-			fmt.Printf("⚠️  Failed to add git remote: %v\n", err)
+			fmt.Fprintf(out, "⚠️  Failed to add git remote: %v\n", err)
 		}
 	}
 
-	// Initial commit with timeout and better error handling
-	addCmd := exec.Command("git", "add", ".")
-	if output, err := addCmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to stage files: %w (output: %s)", err, string(output))
+	if err := runGit(timeout, "add", "."); err != nil {
+		return fmt.Errorf("failed to stage files: %w", err)
 	}
 
 	// Use properly formatted commit message that passes pre-commit hooks
 	commitMsg := fmt.Sprintf("feat: initialize %s project\n\nGenerated from go-template-project", config.ProjectName)
-	commitCmd := exec.Command("git", "commit", "-m", commitMsg)
+	return commitWithRetry(commitMsg, timeout)
+}
 
-	// Set a timeout for the git commit to prevent hanging
-	done := make(chan error, 1)
-	go func() {
-		output, err := commitCmd.CombinedOutput()
-		if err != nil {
-			done <- fmt.Errorf("failed to create initial commit: %w (output: %s)", err, string(output))
-		} else {
-			done <- nil
-		}
-	}()
+// commitWithRetry creates the initial commit. Slow or failing pre-commit
+// hooks are the usual culprit when it fails, so it retries once with
+// --no-verify before giving up.
+func commitWithRetry(message string, timeout time.Duration) error {
+	err := runGit(timeout, "commit", "-m", message)
+	if err == nil {
+		return nil
+	}
 
-	select {
-	case err := <-done:
-		return err
-	case <-time.After(10 * time.Second):
-		if commitCmd.Process != nil {
-			if err := commitCmd.Process.Kill(); err != nil {
-				// Log kill error but don't fail the timeout handling
-				fmt.Printf("Warning: failed to kill git commit process: %v\n", err)
-			}
-		}
-		return fmt.Errorf("git commit timed out after 10 seconds")
+	fmt.Fprintf(out, "⚠️  Initial commit failed: %v\n", err)
+	fmt.Fprintln(out, "   Retrying without pre-commit hooks (--no-verify)...")
+
+	// A killed commit can leave the index locked
+	if err := os.Remove(filepath.Join(".git", "index.lock")); err != nil && !os.IsNotExist(err) {
+		fmt.Fprintf(out, "⚠️  Failed to remove stale git index lock: %v\n", err)
+	}
+
+	if retryErr := runGit(timeout, "commit", "--no-verify", "-m", message); retryErr != nil {
+		return fmt.Errorf("failed to create initial commit: %w", retryErr)
+	}
+
+	fmt.Fprintln(out, "✅ Initial commit created without hooks; run 'pre-commit run --all-files' to check it")
+	return nil
+}
+
+// runGit runs git with args, killing it after timeout. Errors include the
+// command's combined output.
+func runGit(timeout time.Duration, args ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	// Don't wait on pipes held open by hook subprocesses after a kill
+	cmd.WaitDelay = time.Second
+
+	output, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("git %s timed out after %s", args[0], timeout)
+	}
+	if err != nil {
+		return fmt.Errorf("git %s: %w (output: %s)", args[0], err, strings.TrimSpace(string(output)))
 	}
+	return nil
 }
 
 func setupPreCommitHooks() error {
@@ -894,7 +2239,7 @@ func setupPreCommitHooks() error {
 // Helper functions
 
 func prompt(reader *bufio.Reader, question string) string {
-	fmt.Printf("%s: ", question)
+	fmt.Fprintf(out, "%s: ", question)
 	answer, err := reader.ReadString('\n')
 	if err != nil {
 		return ""
@@ -903,7 +2248,7 @@ func prompt(reader *bufio.Reader, question string) string {
 }
 
 func promptWithDefault(reader *bufio.Reader, question, defaultValue string) string {
-	fmt.Printf("%s [%s]: ", question, defaultValue)
+	fmt.Fprintf(out, "%s [%s]: ", question, defaultValue)
 	answer, err := reader.ReadString('\n')
 	if err != nil {
 		return defaultValue
@@ -915,13 +2260,35 @@ func promptWithDefault(reader *bufio.Reader, question, defaultValue string) stri
 	return answer
 }
 
+// promptValid asks question until validate accepts the answer, explaining
+// each rejection, and gives up after maxPromptAttempts or at end of input.
+func promptValid(reader *bufio.Reader, question, defaultValue string, validate func(string) error) (string, error) {
+	for attempt := 1; ; attempt++ {
+		fmt.Fprintf(out, "%s [%s]: ", question, defaultValue)
+		answer, readErr := reader.ReadString('\n')
+		answer = strings.TrimSpace(answer)
+		if answer == "" {
+			answer = defaultValue
+		}
+
+		err := validate(answer)
+		if err == nil {
+			return answer, nil
+		}
+		if readErr != nil || attempt == maxPromptAttempts {
+			return "", fmt.Errorf("%q: %w", answer, err)
+		}
+		fmt.Fprintf(out, "   ❌ %q %v, please try again\n", answer, err)
+	}
+}
+
 func promptBool(reader *bufio.Reader, question string, defaultValue bool) bool {
 	defaultStr := "y/N"
 	if defaultValue {
 		defaultStr = "Y/n"
 	}
 
-	fmt.Printf("%s [%s]: ", question, defaultStr)
+	fmt.Fprintf(out, "%s [%s]: ", question, defaultStr)
 	answer, err := reader.ReadString('\n')
 	if err != nil {
 		return defaultValue
@@ -934,20 +2301,138 @@ func promptBool(reader *bufio.Reader, question string, defaultValue bool) bool {
 	return answer == "y" || answer == "yes"
 }
 
+// defaultModulePathFor derives the suggested module path from the git remote,
+// so GitLab or Bitbucket remotes yield e.g. gitlab.com/group/name. Without a
+// usable remote it falls back to github.com/your-org/<project>.
+func defaultModulePathFor(remote, projectName string) string {
+	if host, path, ok := parseGitRemote(remote); ok {
+		return host + "/" + path
+	}
+	return fmt.Sprintf("github.com/your-org/%s", projectName)
+}
+
+// parseGitRemote splits an scp-style (git@host:group/repo.git) or URL-style
+// (https://host/group/repo.git, ssh://git@host/group/repo) remote into its
+// lowercased host and repository path.
+func parseGitRemote(remote string) (host, path string, ok bool) {
+	remote = strings.TrimSpace(remote)
+	if remote == "" {
+		return "", "", false
+	}
+
+	if u, err := url.Parse(remote); err == nil && u.Scheme != "" && u.Host != "" {
+		host, path = u.Hostname(), u.Path
+	} else if user, rest, found := strings.Cut(remote, "@"); found && !strings.Contains(user, "/") {
+		host, path, found = strings.Cut(rest, ":")
+		if !found {
+			return "", "", false
+		}
+	} else {
+		return "", "", false
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if host == "" || path == "" {
+		return "", "", false
+	}
+	return strings.ToLower(host), path, true
+}
+
+// CloneURL returns the URL shown in the generated README's clone instructions:
+// the configured remote, or an https URL derived from the module path.
+func (c ProjectConfig) CloneURL() string {
+	if c.GitRemote != "" {
+		return c.GitRemote
+	}
+	return "https://" + c.ModulePath + ".git"
+}
+
 func isValidProjectName(name string) bool {
-	matched, err := regexp.MatchString(projectNamePattern, name)
-	if err != nil {
-		return false
+	return validateProjectName(name) == nil
+}
+
+// validateProjectName explains why name can't be used as a project name.
+func validateProjectName(name string) error {
+	switch {
+	case name == "":
+		return errors.New("must not be empty")
+	case len(name) < 2:
+		return errors.New("must be at least 2 characters")
+	}
+	if i := strings.IndexFunc(name, func(r rune) bool {
+		return !(r == '-' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	}); i >= 0 {
+		return fmt.Errorf("must contain only letters, numbers, and hyphens (found %q)", name[i:i+1])
+	}
+	if !regexp.MustCompile(projectNamePattern).MatchString(name) {
+		return errors.New("must start and end with a letter or number")
 	}
-	return matched && len(name) > 0
+	return nil
 }
 
+// isValidModulePath reports whether path is a usable Go module path: a
+// dotted host followed by one or more path elements, e.g. github.com/org/name,
+// gitlab.com/group/subgroup/service, gopkg.in/yaml.v3 or example.com/mod/v2.
 func isValidModulePath(path string) bool {
-	matched, err := regexp.MatchString(modulePathPattern, path)
+	return validateModulePath(path) == nil
+}
+
+// validateModulePath explains why path isn't a usable Go module path.
+func validateModulePath(path string) error {
+	elements := strings.Split(path, "/")
+	if len(elements) < 2 {
+		return errors.New("must be a host followed by a path, e.g. github.com/your-org/name")
+	}
+
+	if !regexp.MustCompile(moduleHostPattern).MatchString(elements[0]) {
+		return fmt.Errorf("host %q must be a lowercase domain name such as github.com", elements[0])
+	}
+
+	elementRe := regexp.MustCompile(moduleElementPattern)
+	for _, element := range elements[1:] {
+		if element == "" {
+			return errors.New("must not contain empty path elements")
+		}
+		if !elementRe.MatchString(element) {
+			return fmt.Errorf("path element %q may only contain letters, numbers, and . _ ~ - and must not start or end with a dot", element)
+		}
+	}
+
+	return nil
+}
+
+func isValidGoVersion(version string) bool {
+	return validateGoVersion(version) == nil
+}
+
+// validateGoVersion explains why version can't be used in the go directive.
+func validateGoVersion(version string) error {
+	if !regexp.MustCompile(goVersionPattern).MatchString(version) {
+		return errors.New("expected a version like 1.23 or 1.23.4")
+	}
+	return nil
+}
+
+// detectGoVersion returns the major.minor version of the local Go toolchain,
+// falling back to defaultGoVersion if it can't be determined.
+func detectGoVersion() string {
+	output, err := exec.Command("go", "env", "GOVERSION").Output()
 	if err != nil {
-		return false
+		return defaultGoVersion
+	}
+
+	// GOVERSION looks like "go1.23.4" (or "go1.24rc1", "devel ..." for unreleased builds)
+	version := strings.TrimPrefix(strings.TrimSpace(string(output)), "go")
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return defaultGoVersion
+	}
+
+	majorMinor := parts[0] + "." + parts[1]
+	if !isValidGoVersion(majorMinor) {
+		return defaultGoVersion
 	}
-	return matched
+	return majorMinor
 }
 
 func getGitConfig(key, fallback string) string {
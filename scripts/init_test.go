@@ -0,0 +1,1542 @@
+package main
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+)
+
+// chdirTemp creates a temporary directory with the given files and makes it
+// the working directory for the duration of the test.
+func chdirTemp(t *testing.T, files ...string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for _, file := range files {
+		path := filepath.Join(dir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("package main\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(wd); err != nil {
+			t.Errorf("Failed to restore working directory: %v", err)
+		}
+	})
+
+	return dir
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func TestParseFlags(t *testing.T) {
+	opts, err := parseFlags([]string{"--grpc"})
+	if err != nil {
+		t.Fatalf("parseFlags() returned error: %v", err)
+	}
+	if !opts.GRPC {
+		t.Error("Expected --grpc to set GRPC")
+	}
+
+	if _, err := parseFlags([]string{"--unknown"}); err == nil {
+		t.Error("Expected error for unknown flag")
+	}
+}
+
+func TestRemoveUnwantedComponentsGRPC(t *testing.T) {
+	grpcFiles := []string{"cmd/grpc/main.go", "proto/service.proto"}
+
+	t.Run("enabled", func(t *testing.T) {
+		chdirTemp(t, grpcFiles...)
+
+		config := &ProjectConfig{EnableCLI: true, EnableServer: true, EnableGRPC: true, EnableDocs: true}
+		if _, err := removeUnwantedComponents(config); err != nil {
+			t.Fatalf("removeUnwantedComponents() returned error: %v", err)
+		}
+
+		for _, file := range grpcFiles {
+			if !exists(file) {
+				t.Errorf("Expected %s to be kept when gRPC is enabled", file)
+			}
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		chdirTemp(t, grpcFiles...)
+
+		config := &ProjectConfig{EnableCLI: true, EnableServer: true, EnableGRPC: false, EnableDocs: true}
+		if _, err := removeUnwantedComponents(config); err != nil {
+			t.Fatalf("removeUnwantedComponents() returned error: %v", err)
+		}
+
+		for _, dir := range []string{"cmd/grpc", "proto"} {
+			if exists(dir) {
+				t.Errorf("Expected %s to be removed when gRPC is disabled", dir)
+			}
+		}
+	})
+}
+
+func TestRemoveUnwantedComponentsScheduler(t *testing.T) {
+	schedulerFiles := []string{"cmd/scheduler/main.go", "internal/cron/cron.go"}
+
+	t.Run("enabled", func(t *testing.T) {
+		chdirTemp(t, schedulerFiles...)
+
+		config := &ProjectConfig{EnableCLI: true, EnableServer: true, EnableScheduler: true, EnableDocs: true}
+		if _, err := removeUnwantedComponents(config); err != nil {
+			t.Fatalf("removeUnwantedComponents() returned error: %v", err)
+		}
+
+		for _, file := range schedulerFiles {
+			if !exists(file) {
+				t.Errorf("Expected %s to be kept when the scheduler is enabled", file)
+			}
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		chdirTemp(t, schedulerFiles...)
+
+		config := &ProjectConfig{EnableCLI: true, EnableServer: true, EnableScheduler: false, EnableDocs: true}
+		if _, err := removeUnwantedComponents(config); err != nil {
+			t.Fatalf("removeUnwantedComponents() returned error: %v", err)
+		}
+
+		for _, dir := range []string{"cmd/scheduler", "internal/cron"} {
+			if exists(dir) {
+				t.Errorf("Expected %s to be removed when the scheduler is disabled", dir)
+			}
+		}
+	})
+}
+
+func TestGenerateRunCommandsScheduler(t *testing.T) {
+	withScheduler := generateRunCommands(&ProjectConfig{EnableScheduler: true})
+	if !strings.Contains(withScheduler, "make run-scheduler") {
+		t.Errorf("Expected run-scheduler command, got %q", withScheduler)
+	}
+
+	withoutScheduler := generateRunCommands(&ProjectConfig{EnableCLI: true})
+	if strings.Contains(withoutScheduler, "make run-scheduler") {
+		t.Errorf("Unexpected run-scheduler command, got %q", withoutScheduler)
+	}
+}
+
+func TestGenerateRunCommandsGRPC(t *testing.T) {
+	withGRPC := generateRunCommands(&ProjectConfig{EnableGRPC: true})
+	if !strings.Contains(withGRPC, "make run-grpc") {
+		t.Errorf("Expected run-grpc command, got %q", withGRPC)
+	}
+
+	withoutGRPC := generateRunCommands(&ProjectConfig{EnableCLI: true})
+	if strings.Contains(withoutGRPC, "make run-grpc") {
+		t.Errorf("Unexpected run-grpc command, got %q", withoutGRPC)
+	}
+}
+
+func TestGenerateReadmeGRPC(t *testing.T) {
+	chdirTemp(t)
+
+	config := &ProjectConfig{ProjectName: "svc", EnableGRPC: true}
+	if err := generateReadme(config, nil, newRollback()); err != nil {
+		t.Fatalf("generateReadme() returned error: %v", err)
+	}
+
+	content, err := os.ReadFile("README.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "make run-grpc") {
+		t.Error("Expected README to document make run-grpc")
+	}
+}
+
+func TestRemoveUnwantedComponentsDatabase(t *testing.T) {
+	databaseFiles := []string{"internal/store/store.go", "migrations/0001_init.up.sql"}
+
+	t.Run("enabled", func(t *testing.T) {
+		chdirTemp(t, databaseFiles...)
+
+		config := &ProjectConfig{EnableCLI: true, EnableDatabase: true, EnableDocs: true}
+		if _, err := removeUnwantedComponents(config); err != nil {
+			t.Fatalf("removeUnwantedComponents() returned error: %v", err)
+		}
+
+		for _, file := range databaseFiles {
+			if !exists(file) {
+				t.Errorf("Expected %s to be kept when the database is enabled", file)
+			}
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		chdirTemp(t, databaseFiles...)
+
+		config := &ProjectConfig{EnableCLI: true, EnableDatabase: false, EnableDocs: true}
+		if _, err := removeUnwantedComponents(config); err != nil {
+			t.Fatalf("removeUnwantedComponents() returned error: %v", err)
+		}
+
+		for _, dir := range []string{"internal/store", "migrations"} {
+			if exists(dir) {
+				t.Errorf("Expected %s to be removed when the database is disabled", dir)
+			}
+		}
+	})
+}
+
+func TestCleanupTemplateArtifactsDatabaseE2E(t *testing.T) {
+	chdirTemp(t, "tests/e2e/database_e2e_test.go")
+
+	config := &ProjectConfig{EnableCLI: true, EnableServer: true, EnableWorker: true, EnableE2ETests: true}
+	if _, err := cleanupTemplateArtifacts(config); err != nil {
+		t.Fatalf("cleanupTemplateArtifacts() returned error: %v", err)
+	}
+
+	if exists("tests/e2e/database_e2e_test.go") {
+		t.Error("Expected database e2e test to be removed when the database is disabled")
+	}
+}
+
+func TestCleanupTemplateArtifactsSmokeTests(t *testing.T) {
+	chdirTemp(t,
+		"tests/smoke/config_smoke_test.go",
+		"tests/smoke/cli_smoke_test.go",
+		"tests/smoke/server_smoke_test.go",
+	)
+
+	config := &ProjectConfig{EnableCLI: true, EnableServer: false, EnableSmokeTests: true}
+	if _, err := cleanupTemplateArtifacts(config); err != nil {
+		t.Fatalf("cleanupTemplateArtifacts() returned error: %v", err)
+	}
+
+	if exists("tests/smoke/server_smoke_test.go") {
+		t.Error("Expected server smoke test to be removed when the server is disabled")
+	}
+	for _, file := range []string{"tests/smoke/config_smoke_test.go", "tests/smoke/cli_smoke_test.go"} {
+		if !exists(file) {
+			t.Errorf("Expected %s to be kept", file)
+		}
+	}
+}
+
+func TestCleanupTemplateArtifactsSummary(t *testing.T) {
+	chdirTemp(t,
+		"tests/e2e/init_e2e_test.go",
+		"tests/e2e/cli_e2e_test.go",
+		"tests/e2e/server_e2e_test.go",
+		"tests/e2e/worker_e2e_test.go",
+		"tests/e2e/database_e2e_test.go",
+		"tests/smoke/cli_smoke_test.go",
+	)
+
+	config := &ProjectConfig{EnableCLI: true, EnableServer: false, EnableWorker: false, EnableSmokeTests: true, EnableE2ETests: true}
+	summary, err := cleanupTemplateArtifacts(config)
+	if err != nil {
+		t.Fatalf("cleanupTemplateArtifacts() returned error: %v", err)
+	}
+
+	wantRemoved := []string{
+		"tests/e2e/init_e2e_test.go",
+		"tests/e2e/server_e2e_test.go",
+		"tests/e2e/worker_e2e_test.go",
+		"tests/e2e/database_e2e_test.go",
+	}
+	if !reflect.DeepEqual(summary.Removed, wantRemoved) {
+		t.Errorf("Expected removed %v, got %v", wantRemoved, summary.Removed)
+	}
+
+	wantKept := []string{"tests/smoke/cli_smoke_test.go", "tests/e2e/cli_e2e_test.go"}
+	if !reflect.DeepEqual(summary.Kept, wantKept) {
+		t.Errorf("Expected kept %v, got %v", wantKept, summary.Kept)
+	}
+}
+
+func TestCleanupTemplateArtifactsReportsFailingFile(t *testing.T) {
+	// A non-empty directory in place of the file makes os.Remove fail
+	chdirTemp(t, "tests/e2e/worker_e2e_test.go/keep")
+
+	config := &ProjectConfig{EnableCLI: true, EnableServer: true, EnableDatabase: true, EnableE2ETests: true}
+	_, err := cleanupTemplateArtifacts(config)
+	if err == nil {
+		t.Fatal("Expected error when a file can't be removed")
+	}
+	if !strings.Contains(err.Error(), "tests/e2e/worker_e2e_test.go (worker disabled)") {
+		t.Errorf("Expected error to name the file and reason, got: %v", err)
+	}
+}
+
+func TestTestTiersDisablingOnlyE2E(t *testing.T) {
+	chdirTemp(t,
+		"internal/handlers/integration_test.go",
+		"internal/handlers/router_test.go",
+		"tests/smoke/config_smoke_test.go",
+		"tests/e2e/cli_e2e_test.go",
+	)
+
+	config := &ProjectConfig{
+		EnableCLI:              true,
+		EnableServer:           true,
+		EnableIntegrationTests: true,
+		EnableSmokeTests:       true,
+		EnableE2ETests:         false,
+	}
+	if _, err := removeUnwantedComponents(config); err != nil {
+		t.Fatalf("removeUnwantedComponents() returned error: %v", err)
+	}
+	if _, err := cleanupTemplateArtifacts(config); err != nil {
+		t.Fatalf("cleanupTemplateArtifacts() returned error: %v", err)
+	}
+
+	if exists("tests/e2e") {
+		t.Error("Expected tests/e2e to be removed when E2E tests are disabled")
+	}
+	for _, path := range []string{
+		"internal/handlers/integration_test.go",
+		"internal/handlers/router_test.go",
+		"tests/smoke/config_smoke_test.go",
+	} {
+		if !exists(path) {
+			t.Errorf("Expected %s to be kept", path)
+		}
+	}
+
+	makefile := strings.Join([]string{
+		".PHONY: test-unit test-integration test-smoke test-e2e test-all",
+		"test-integration: ## Run integration tests",
+		"\tgo test -run Integration ./...",
+		"",
+		"test-smoke: ## Run smoke tests",
+		"\tgo test -tags=smoke ./tests/smoke/...",
+		"",
+		"test-e2e: ## Run end-to-end tests",
+		"\tgo test -tags=e2e ./tests/e2e/...",
+		"",
+		"test-all: test-unit test-integration test-smoke test-e2e ## Run all test categories",
+		"",
+	}, "\n")
+	got := filterMakefile(makefile, removedTestTargets(config), nil)
+
+	for _, want := range []string{"test-integration: ##", "test-smoke: ##", "test-all: test-unit test-integration test-smoke ## Run all"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected Makefile to contain %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "test-e2e") {
+		t.Errorf("Expected test-e2e to be removed from the Makefile, got:\n%s", got)
+	}
+}
+
+func TestTestTiersDisablingIntegrationAndSmoke(t *testing.T) {
+	chdirTemp(t,
+		"internal/handlers/integration_test.go",
+		"tests/smoke/config_smoke_test.go",
+	)
+
+	config := &ProjectConfig{EnableCLI: true, EnableServer: true}
+	if _, err := removeUnwantedComponents(config); err != nil {
+		t.Fatalf("removeUnwantedComponents() returned error: %v", err)
+	}
+	summary, err := cleanupTemplateArtifacts(config)
+	if err != nil {
+		t.Fatalf("cleanupTemplateArtifacts() returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(summary.Removed, []string{"internal/handlers/integration_test.go"}) {
+		t.Errorf("Expected only the integration test to be reported removed, got %v", summary.Removed)
+	}
+	if exists("tests") {
+		t.Error("Expected an empty tests/ directory to be removed")
+	}
+}
+
+func TestGenerateTestingFeatures(t *testing.T) {
+	tests := []struct {
+		config *ProjectConfig
+		want   string
+	}{
+		{&ProjectConfig{}, "Unit"},
+		{&ProjectConfig{EnableSmokeTests: true}, "Unit and smoke"},
+		{&ProjectConfig{EnableIntegrationTests: true, EnableSmokeTests: true}, "Unit, integration, and smoke"},
+		{&ProjectConfig{EnableIntegrationTests: true, EnableSmokeTests: true, EnableE2ETests: true}, "Unit, integration, smoke, and E2E"},
+	}
+
+	for _, tt := range tests {
+		if got := generateTestingFeatures(tt.config); got != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, got)
+		}
+	}
+}
+
+func TestFilterWorkflowSteps(t *testing.T) {
+	workflow := strings.Join([]string{
+		"    steps:",
+		"    - name: Checkout code",
+		"      uses: actions/checkout@v4",
+		"",
+		"    - name: Run integration tests",
+		"      run: make test-integration",
+		"      env:",
+		"        DATABASE_URL: postgres://localhost/testdb",
+		"",
+		"    - name: Run smoke tests",
+		"      run: make test-smoke",
+		"",
+		"    - name: Run E2E tests",
+		"      run: make test-e2e",
+		"",
+		"  release:",
+		"    name: Release",
+		"",
+	}, "\n")
+
+	got := filterWorkflowSteps(workflow, []string{"test-integration", "test-e2e"})
+
+	want := strings.Join([]string{
+		"    steps:",
+		"    - name: Checkout code",
+		"      uses: actions/checkout@v4",
+		"",
+		"    - name: Run smoke tests",
+		"      run: make test-smoke",
+		"",
+		"  release:",
+		"    name: Release",
+		"",
+	}, "\n")
+	if got != want {
+		t.Errorf("Unexpected workflow:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestGenerateReadmeDatabaseURL(t *testing.T) {
+	tests := []struct {
+		name           string
+		enableDatabase bool
+		wantRow        bool
+	}{
+		{"enabled", true, true},
+		{"disabled", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chdirTemp(t)
+
+			config := &ProjectConfig{ProjectName: "svc", EnableDatabase: tt.enableDatabase}
+			if err := generateReadme(config, nil, newRollback()); err != nil {
+				t.Fatalf("generateReadme() returned error: %v", err)
+			}
+
+			content, err := os.ReadFile("README.md")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := strings.Contains(string(content), "DATABASE_URL"); got != tt.wantRow {
+				t.Errorf("README contains DATABASE_URL = %t, want %t", got, tt.wantRow)
+			}
+		})
+	}
+}
+
+func TestIsValidModulePath(t *testing.T) {
+	tests := []struct {
+		path  string
+		valid bool
+	}{
+		// Valid module paths
+		{"github.com/org/name", true},
+		{"github.com/org/team/service", true},
+		{"gitlab.com/group/subgroup/project", true},
+		{"gopkg.in/yaml.v3", true},
+		{"gopkg.in/foo.v2", true},
+		{"example.com/mod/v2", true},
+		{"go.uber.org/zap", true},
+		{"bitbucket.org/team/repo_name", true},
+		{"git.internal.example.com/platform/api-server", true},
+		{"github.com/Org/Mixed-Case", true},
+
+		// Invalid module paths
+		{"", false},
+		{"name", false},
+		{"invalid-module-path-no-slash", false},
+		{"localhost/org/name", false},
+		{"/github.com/org/name", false},
+		{"github.com/org/name/", false},
+		{"github.com//name", false},
+		{"github.com/org name/project", false},
+		{"github.com/org/na me", false},
+		{"github.com/org/.hidden", false},
+		{"github.com/org/trailing.", false},
+		{"GitHub.com/org/name", false},
+		{"-github.com/org/name", false},
+		{"github.com/org/name?x=1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := isValidModulePath(tt.path); got != tt.valid {
+				t.Errorf("isValidModulePath(%q) = %t, want %t", tt.path, got, tt.valid)
+			}
+		})
+	}
+}
+
+func TestPlainWriterStripsEmoji(t *testing.T) {
+	var buf strings.Builder
+	w := plainWriter{w: &buf}
+
+	lines := []string{
+		"🚀 Go Template Project Initialization\n",
+		"⚠️  Failed to initialize git: boom\n",
+		"ℹ️  Skipping git initialization (test environment)\n",
+		"🗑️  Removing initialization script...\n",
+		"   ✅ Original files restored\n",
+		"❌ Initialization cancelled\n",
+		"🧹 Cleaning up template artifacts...\n",
+		"↩️  Initialization failed, restoring original files...\n",
+	}
+	for _, line := range lines {
+		if _, err := w.Write([]byte(line)); err != nil {
+			t.Fatalf("Write() returned error: %v", err)
+		}
+	}
+
+	for _, r := range buf.String() {
+		if r > unicode.MaxASCII {
+			t.Fatalf("Expected ASCII-only output, found %q in:\n%s", r, buf.String())
+		}
+	}
+
+	if !strings.Contains(buf.String(), "[warn] Failed to initialize git") {
+		t.Errorf("Expected warning label in output, got:\n%s", buf.String())
+	}
+}
+
+func TestUpdateGoModUsesGoVersion(t *testing.T) {
+	chdirTemp(t)
+
+	config := &ProjectConfig{ModulePath: "github.com/example/svc", GoVersion: "1.21"}
+	if err := updateGoMod(config, newRollback()); err != nil {
+		t.Fatalf("updateGoMod() returned error: %v", err)
+	}
+
+	content, err := os.ReadFile("go.mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(content), "\ngo 1.21\n") {
+		t.Errorf("Expected go.mod to declare go 1.21, got:\n%s", content)
+	}
+}
+
+func TestParseFlagsGoVersion(t *testing.T) {
+	opts, err := parseFlags([]string{"--go-version", "1.22"})
+	if err != nil {
+		t.Fatalf("parseFlags() returned error: %v", err)
+	}
+	if opts.GoVersion != "1.22" {
+		t.Errorf("Expected Go version 1.22, got %q", opts.GoVersion)
+	}
+
+	for _, bad := range []string{"latest", "go1.22", "2", "1.x"} {
+		if _, err := parseFlags([]string{"--go-version", bad}); err == nil {
+			t.Errorf("Expected error for --go-version %q", bad)
+		}
+	}
+}
+
+func TestDetectGoVersion(t *testing.T) {
+	if version := detectGoVersion(); !isValidGoVersion(version) {
+		t.Errorf("detectGoVersion() returned invalid version %q", version)
+	}
+}
+
+func TestGenerateMakefileWithoutServer(t *testing.T) {
+	template, err := os.ReadFile("../Makefile")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chdirTemp(t)
+	if err := os.WriteFile("Makefile", template, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &ProjectConfig{EnableCLI: true, EnableServer: false, EnableWorker: true, EnableGRPC: true}
+	if err := generateMakefile(config, newRollback()); err != nil {
+		t.Fatalf("generateMakefile() returned error: %v", err)
+	}
+
+	content, err := os.ReadFile("Makefile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	makefile := string(content)
+
+	if strings.Contains(makefile, "run-server") {
+		t.Error("Expected Makefile to have no run-server target")
+	}
+	if strings.Contains(makefile, "./cmd/server") {
+		t.Error("Expected Makefile to not build ./cmd/server")
+	}
+	if strings.Contains(makefile, "run-scheduler") || strings.Contains(makefile, "./cmd/scheduler") {
+		t.Error("Expected Makefile to drop the disabled scheduler")
+	}
+	for _, target := range []string{"run-cli:", "run-worker:", "run-grpc:", "proto:"} {
+		if !strings.Contains(makefile, target) {
+			t.Errorf("Expected Makefile to keep %s target", target)
+		}
+	}
+}
+
+func TestDefaultModulePathFor(t *testing.T) {
+	tests := []struct {
+		remote string
+		want   string
+	}{
+		{"", "github.com/your-org/svc"},
+		{"git@gitlab.com:acme/platform/svc.git", "gitlab.com/acme/platform/svc"},
+		{"https://gitlab.com/acme/svc.git", "gitlab.com/acme/svc"},
+		{"ssh://git@bitbucket.org/acme/svc.git", "bitbucket.org/acme/svc"},
+		{"git@github.com:acme/svc.git", "github.com/acme/svc"},
+		{"not a remote", "github.com/your-org/svc"},
+	}
+
+	for _, tt := range tests {
+		if got := defaultModulePathFor(tt.remote, "svc"); got != tt.want {
+			t.Errorf("defaultModulePathFor(%q) = %q, expected %q", tt.remote, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateReadmeGitLabRemote(t *testing.T) {
+	chdirTemp(t)
+
+	remote := "git@gitlab.com:acme/svc.git"
+	config := &ProjectConfig{
+		ProjectName: "svc",
+		ModulePath:  defaultModulePathFor(remote, "svc"),
+		GitRemote:   remote,
+	}
+	if config.ModulePath != "gitlab.com/acme/svc" {
+		t.Fatalf("Expected gitlab.com module path, got %s", config.ModulePath)
+	}
+
+	if err := generateReadme(config, nil, newRollback()); err != nil {
+		t.Fatalf("generateReadme() returned error: %v", err)
+	}
+
+	content, err := os.ReadFile("README.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "git clone "+remote) {
+		t.Error("Expected README clone instructions to use the GitLab remote")
+	}
+}
+
+func TestGenerateReadmeCloneURLWithoutRemote(t *testing.T) {
+	chdirTemp(t)
+
+	config := &ProjectConfig{ProjectName: "svc", ModulePath: "github.com/your-org/svc"}
+	if err := generateReadme(config, nil, newRollback()); err != nil {
+		t.Fatalf("generateReadme() returned error: %v", err)
+	}
+
+	content, err := os.ReadFile("README.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "git clone https://github.com/your-org/svc.git") {
+		t.Error("Expected README clone instructions to fall back to the module path")
+	}
+}
+
+// fakeGit puts a git stand-in running script first on PATH. Each call's
+// arguments are appended to the returned log file.
+func fakeGit(t *testing.T, script string) string {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("fake git uses a shell script")
+	}
+
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "calls.log")
+	content := "#!/bin/sh\necho \"$@\" >> " + logFile + "\n" + script + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "git"), []byte(content), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return logFile
+}
+
+func gitCalls(t *testing.T, logFile string) []string {
+	t.Helper()
+
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func TestCommitWithRetryFallsBackToNoVerify(t *testing.T) {
+	chdirTemp(t)
+	// Hang unless hooks are skipped, like a slow pre-commit linter
+	logFile := fakeGit(t, `case "$*" in *--no-verify*) exit 0 ;; *) exec sleep 5 ;; esac`)
+
+	start := time.Now()
+	if err := commitWithRetry("init", 200*time.Millisecond); err != nil {
+		t.Fatalf("commitWithRetry() returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("Expected timed-out commit to be killed promptly, took %v", elapsed)
+	}
+
+	calls := gitCalls(t, logFile)
+	if len(calls) != 2 {
+		t.Fatalf("Expected 2 git calls, got %d: %v", len(calls), calls)
+	}
+	if !strings.Contains(calls[1], "--no-verify") {
+		t.Errorf("Expected retry to use --no-verify, got %q", calls[1])
+	}
+}
+
+func TestCommitWithRetryGivesUp(t *testing.T) {
+	chdirTemp(t)
+	logFile := fakeGit(t, `echo "hook failed" >&2; exit 1`)
+
+	err := commitWithRetry("init", time.Second)
+	if err == nil {
+		t.Fatal("Expected error when both commit attempts fail")
+	}
+	if !strings.Contains(err.Error(), "hook failed") {
+		t.Errorf("Expected git output in error, got %v", err)
+	}
+
+	if calls := gitCalls(t, logFile); len(calls) != 2 {
+		t.Errorf("Expected 2 git calls, got %d: %v", len(calls), calls)
+	}
+}
+
+func TestParseFlagsGitTimeout(t *testing.T) {
+	opts, err := parseFlags(nil)
+	if err != nil {
+		t.Fatalf("parseFlags() returned error: %v", err)
+	}
+	if opts.GitTimeout != defaultGitTimeout {
+		t.Errorf("Expected default git timeout %v, got %v", defaultGitTimeout, opts.GitTimeout)
+	}
+
+	opts, err = parseFlags([]string{"--git-timeout", "45s"})
+	if err != nil {
+		t.Fatalf("parseFlags() returned error: %v", err)
+	}
+	if opts.GitTimeout != 45*time.Second {
+		t.Errorf("Expected git timeout 45s, got %v", opts.GitTimeout)
+	}
+
+	if _, err := parseFlags([]string{"--git-timeout", "0s"}); err == nil {
+		t.Error("Expected error for non-positive --git-timeout")
+	}
+}
+
+func TestParseFlagsSkipGit(t *testing.T) {
+	t.Setenv("SKIP_GIT_INIT", "")
+
+	opts, err := parseFlags([]string{"--skip-git"})
+	if err != nil {
+		t.Fatalf("parseFlags() returned error: %v", err)
+	}
+	if !opts.SkipGit {
+		t.Error("Expected --skip-git to set SkipGit")
+	}
+
+	// The legacy env var still works
+	t.Setenv("SKIP_GIT_INIT", "1")
+	opts, err = parseFlags(nil)
+	if err != nil {
+		t.Fatalf("parseFlags() returned error: %v", err)
+	}
+	if !opts.SkipGit {
+		t.Error("Expected SKIP_GIT_INIT to set SkipGit")
+	}
+}
+
+func TestParseFlagsKeepInit(t *testing.T) {
+	opts, err := parseFlags(nil)
+	if err != nil {
+		t.Fatalf("parseFlags() returned error: %v", err)
+	}
+	if opts.KeepInit {
+		t.Error("Expected KeepInit to default to false")
+	}
+
+	opts, err = parseFlags([]string{"--keep-init"})
+	if err != nil {
+		t.Fatalf("parseFlags() returned error: %v", err)
+	}
+	if !opts.KeepInit {
+		t.Error("Expected --keep-init to set KeepInit")
+	}
+}
+
+func TestUpdateImportPathsSkipsVendorGitAndTestdata(t *testing.T) {
+	chdirTemp(t)
+
+	templateImport := "package main\n\nimport _ \"" + templateModulePath + "/internal/app\"\n"
+	files := []string{
+		"cmd/cli/main.go",
+		"vendor/example.com/dep/dep.go",
+		".git/hooks/hook.go",
+		"internal/app/testdata/fixture.go",
+	}
+	for _, file := range files {
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(templateImport), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config := &ProjectConfig{ModulePath: "github.com/acme/svc"}
+	if err := updateImportPaths(config, newRollback()); err != nil {
+		t.Fatalf("updateImportPaths() returned error: %v", err)
+	}
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rewritten := strings.Contains(string(content), "github.com/acme/svc")
+		if file == "cmd/cli/main.go" && !rewritten {
+			t.Errorf("Expected %s to be rewritten", file)
+		}
+		if file != "cmd/cli/main.go" && rewritten {
+			t.Errorf("Expected %s to be left untouched", file)
+		}
+	}
+}
+
+// writeAnswers writes content to an answers file in a temp directory.
+func writeAnswers(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "answers.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadAnswers(t *testing.T) {
+	path := writeAnswers(t, `{
+		"project_name": "my-service",
+		"git_remote": "git@gitlab.com:acme/my-service.git",
+		"go_version": "1.23",
+		"enable_worker": true,
+		"enable_docs": false
+	}`)
+
+	config, err := loadAnswers(path, &initOptions{})
+	if err != nil {
+		t.Fatalf("loadAnswers() returned error: %v", err)
+	}
+
+	if config.ProjectName != "my-service" {
+		t.Errorf("Expected project name 'my-service', got '%s'", config.ProjectName)
+	}
+	if config.ModulePath != "gitlab.com/acme/my-service" {
+		t.Errorf("Expected module path derived from the remote, got '%s'", config.ModulePath)
+	}
+	if !config.EnableWorker || config.EnableDocs {
+		t.Errorf("Expected worker enabled and docs disabled, got worker=%t docs=%t", config.EnableWorker, config.EnableDocs)
+	}
+	if !config.EnableCLI || !config.EnableServer {
+		t.Error("Expected omitted components to keep their prompt defaults")
+	}
+	if config.License != defaultLicense {
+		t.Errorf("Expected default license %s, got %s", defaultLicense, config.License)
+	}
+}
+
+func TestLoadAnswersUnknownKey(t *testing.T) {
+	path := writeAnswers(t, `{"project_name": "my-service", "enable_wroker": true}`)
+
+	_, err := loadAnswers(path, &initOptions{GoVersion: "1.23"})
+	if err == nil {
+		t.Fatal("Expected error for unknown key")
+	}
+	if !strings.Contains(err.Error(), `unknown key "enable_wroker"`) {
+		t.Errorf("Expected error to name the unknown key, got: %v", err)
+	}
+}
+
+func TestLoadAnswersTypeMismatch(t *testing.T) {
+	path := writeAnswers(t, `{"project_name": "my-service", "enable_worker": "yes"}`)
+
+	_, err := loadAnswers(path, &initOptions{GoVersion: "1.23"})
+	if err == nil {
+		t.Fatal("Expected error for non-boolean enable_worker")
+	}
+	if !strings.Contains(err.Error(), `"enable_worker" must be a bool`) {
+		t.Errorf("Expected error to name the mistyped key, got: %v", err)
+	}
+}
+
+func TestInitializeProjectVerboseNamesSteps(t *testing.T) {
+	chdirTemp(t)
+	if err := os.WriteFile("go.mod", []byte("module "+templateModulePath+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fakeGit(t, "exit 0")
+
+	var buf strings.Builder
+	origOut, origVerbose := out, verbose
+	out, verbose = &buf, true
+	defer func() { out, verbose = origOut, origVerbose }()
+
+	config := &ProjectConfig{
+		ProjectName: "svc",
+		ModulePath:  "github.com/example/svc",
+		GoVersion:   "1.23",
+		EnableCLI:   true,
+	}
+	if err := initializeProject(config, &initOptions{GitTimeout: time.Second, KeepInit: true}); err != nil {
+		t.Fatalf("initializeProject() returned error: %v", err)
+	}
+
+	output := buf.String()
+	for _, name := range []string{
+		"updateGoMod",
+		"updateImportPaths",
+		"removeUnwantedComponents",
+		"generateReadme",
+		"generateDockerfile",
+		"initializeGit",
+		"setupPreCommitHooks",
+	} {
+		if !strings.Contains(output, name+": started") {
+			t.Errorf("Expected verbose output to announce %s, got:\n%s", name, output)
+		}
+		if !strings.Contains(output, name+": finished in") && !strings.Contains(output, name+": failed after") {
+			t.Errorf("Expected verbose output to time %s, got:\n%s", name, output)
+		}
+	}
+}
+
+func TestParseFlagsVerbose(t *testing.T) {
+	opts, err := parseFlags([]string{"--verbose"})
+	if err != nil {
+		t.Fatalf("parseFlags() returned error: %v", err)
+	}
+	if !opts.Verbose {
+		t.Error("Expected --verbose to enable verbose output")
+	}
+}
+
+func TestGenerateGovernanceDocs(t *testing.T) {
+	chdirTemp(t)
+
+	config := &ProjectConfig{
+		ProjectName: "svc",
+		Author:      "Jordan Lee",
+		Email:       "jordan@example.com",
+	}
+	rb := newRollback()
+	if err := generateGovernanceDocs(config, rb); err != nil {
+		t.Fatalf("generateGovernanceDocs() returned error: %v", err)
+	}
+
+	contributing, err := os.ReadFile("CONTRIBUTING.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(contributing), "Jordan Lee <jordan@example.com>") {
+		t.Errorf("Expected CONTRIBUTING.md to name the author, got:\n%s", contributing)
+	}
+
+	codeowners, err := os.ReadFile(filepath.Join(".github", "CODEOWNERS"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(codeowners), "* jordan@example.com\n") {
+		t.Errorf("Expected CODEOWNERS to assign the author, got:\n%s", codeowners)
+	}
+
+	// Both files are new, so a rollback removes them
+	if err := rb.restore(); err != nil {
+		t.Fatalf("restore() returned error: %v", err)
+	}
+	if exists("CONTRIBUTING.md") || exists(filepath.Join(".github", "CODEOWNERS")) {
+		t.Error("Expected rollback to remove the generated governance docs")
+	}
+}
+
+func TestParseFlagsGovernance(t *testing.T) {
+	opts, err := parseFlags(nil)
+	if err != nil {
+		t.Fatalf("parseFlags() returned error: %v", err)
+	}
+	if opts.Governance {
+		t.Error("Expected governance docs to be off by default")
+	}
+
+	opts, err = parseFlags([]string{"--governance"})
+	if err != nil {
+		t.Fatalf("parseFlags() returned error: %v", err)
+	}
+	if !opts.Governance {
+		t.Error("Expected --governance to enable governance docs")
+	}
+}
+
+func TestInitializeProjectRefusesCustomizedReadme(t *testing.T) {
+	chdirTemp(t)
+	goMod := "module " + templateModulePath + "\n"
+	if err := os.WriteFile("go.mod", []byte(goMod), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile("README.md", []byte("# my-service\n\nHand-written docs.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf strings.Builder
+	origOut := out
+	out = &buf
+	defer func() { out = origOut }()
+
+	config := &ProjectConfig{ProjectName: "svc", ModulePath: "github.com/example/svc", GoVersion: "1.23"}
+	err := initializeProject(config, &initOptions{GitTimeout: time.Second, SkipGit: true, KeepInit: true})
+	if err == nil {
+		t.Fatal("Expected init to abort in a customized directory")
+	}
+	if !strings.Contains(err.Error(), "--force") {
+		t.Errorf("Expected error to mention --force, got %v", err)
+	}
+	if !strings.Contains(buf.String(), "README.md is not the template README") {
+		t.Errorf("Expected output to name the replaced README, got:\n%s", buf.String())
+	}
+
+	content, err := os.ReadFile("go.mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != goMod {
+		t.Errorf("Expected go.mod to be untouched, got:\n%s", content)
+	}
+}
+
+func TestEnsurePristineForce(t *testing.T) {
+	chdirTemp(t)
+	if err := os.WriteFile("README.md", []byte("# my-service\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ensurePristine(true); err != nil {
+		t.Errorf("Expected --force to skip the check, got %v", err)
+	}
+}
+
+func TestDetectLocalChangesGit(t *testing.T) {
+	chdirTemp(t)
+	if err := os.Mkdir(".git", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	fakeGit(t, `echo " M internal/config/config.go"`)
+
+	findings := detectLocalChanges()
+	if len(findings) != 1 || !strings.Contains(findings[0], "internal/config/config.go") {
+		t.Errorf("Expected the modified file to be reported, got %v", findings)
+	}
+}
+
+func TestRemoveUnwantedComponentsReportsRemovedPaths(t *testing.T) {
+	chdirTemp(t,
+		"cmd/cli/main.go",
+		"cmd/server/main.go",
+		"internal/handlers/router.go",
+		"cmd/worker/main.go",
+		"cmd/grpc/main.go",
+		"proto/service.proto",
+		"internal/store/store.go",
+		"docs/index.md",
+		"tests/e2e/cli_e2e_test.go",
+	)
+
+	var buf strings.Builder
+	origOut := out
+	out = &buf
+	defer func() { out = origOut }()
+
+	config := &ProjectConfig{
+		EnableCLI:      true,
+		EnableServer:   false,
+		EnableWorker:   true,
+		EnableGRPC:     false,
+		EnableDatabase: false,
+		EnableDocs:     true,
+		EnableE2ETests: true,
+	}
+	removed, err := removeUnwantedComponents(config)
+	if err != nil {
+		t.Fatalf("removeUnwantedComponents() returned error: %v", err)
+	}
+
+	// The scheduler and migrations are disabled too but absent, so skipped;
+	// the worker still needs internal/handlers
+	want := []string{"cmd/server", "cmd/grpc", "proto", "internal/store"}
+	if !reflect.DeepEqual(removed, want) {
+		t.Errorf("Expected removed paths %v, got %v", want, removed)
+	}
+
+	for _, path := range want {
+		if exists(path) {
+			t.Errorf("Expected %s to be removed", path)
+		}
+		if !strings.Contains(buf.String(), "Removing "+path+"/") {
+			t.Errorf("Expected removal of %s to be logged, got:\n%s", path, buf.String())
+		}
+	}
+	for _, path := range []string{"cmd/cli", "cmd/worker", "internal/handlers", "docs", "tests/e2e"} {
+		if !exists(path) {
+			t.Errorf("Expected enabled component %s to be kept", path)
+		}
+	}
+}
+
+func TestPromptValidRepromptsWithReason(t *testing.T) {
+	var buf strings.Builder
+	origOut := out
+	out = &buf
+	defer func() { out = origOut }()
+
+	reader := bufio.NewReader(strings.NewReader("my_service\n-svc\nmy-service\n"))
+	got, err := promptValid(reader, "Project name", "default", validateProjectName)
+	if err != nil {
+		t.Fatalf("promptValid() returned error: %v", err)
+	}
+	if got != "my-service" {
+		t.Errorf("Expected the first valid answer, got %q", got)
+	}
+
+	output := buf.String()
+	if n := strings.Count(output, "Project name [default]: "); n != 3 {
+		t.Errorf("Expected 3 prompts, got %d:\n%s", n, output)
+	}
+	for _, reason := range []string{
+		`"my_service" must contain only letters, numbers, and hyphens (found "_")`,
+		`"-svc" must start and end with a letter or number`,
+	} {
+		if !strings.Contains(output, reason) {
+			t.Errorf("Expected output to explain %s, got:\n%s", reason, output)
+		}
+	}
+}
+
+func TestPromptValidGivesUp(t *testing.T) {
+	var buf strings.Builder
+	origOut := out
+	out = &buf
+	defer func() { out = origOut }()
+
+	tests := []struct {
+		name    string
+		input   string
+		prompts int
+	}{
+		{name: "too many attempts", input: "a\nb\nc\nd\n", prompts: maxPromptAttempts},
+		{name: "end of input", input: "a\n", prompts: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			reader := bufio.NewReader(strings.NewReader(tt.input))
+			_, err := promptValid(reader, "Go module path", "x", validateModulePath)
+			if err == nil {
+				t.Fatal("Expected error after invalid answers")
+			}
+			if !strings.Contains(err.Error(), "must be a host followed by a path") {
+				t.Errorf("Expected error to give the reason, got: %v", err)
+			}
+			if n := strings.Count(buf.String(), "Go module path [x]: "); n != tt.prompts {
+				t.Errorf("Expected %d prompts, got %d", tt.prompts, n)
+			}
+		})
+	}
+}
+
+func TestGatherProjectInfoReprompts(t *testing.T) {
+	var buf strings.Builder
+	origOut := out
+	out = &buf
+	defer func() { out = origOut }()
+
+	input := strings.Join([]string{
+		"bad name", "my-service", // project name, re-asked once
+		"",                                         // git remote
+		"github.com", "github.com/acme/my-service", // module path, re-asked once
+		"", "", "", "", // description, author, email, license
+		"1.x", "1.23", // go version, re-asked once
+		"", "", "", "", "", "", "", // components
+		"", "", "", // test tiers
+		"y", // proceed
+	}, "\n") + "\n"
+
+	config, err := gatherProjectInfo(bufio.NewReader(strings.NewReader(input)), &initOptions{GoVersion: "1.23"})
+	if err != nil {
+		t.Fatalf("gatherProjectInfo() returned error: %v\n%s", err, buf.String())
+	}
+	if config.ProjectName != "my-service" || config.ModulePath != "github.com/acme/my-service" || config.GoVersion != "1.23" {
+		t.Errorf("Expected corrected answers, got name=%q module=%q go=%q",
+			config.ProjectName, config.ModulePath, config.GoVersion)
+	}
+	if n := strings.Count(buf.String(), "please try again"); n != 3 {
+		t.Errorf("Expected 3 rejections, got %d:\n%s", n, buf.String())
+	}
+}
+
+func TestLoadAnswersInvalidValues(t *testing.T) {
+	tests := []struct {
+		answers string
+		want    string
+	}{
+		{`{"project_name": "my_service"}`, `project_name "my_service": must contain only letters, numbers, and hyphens`},
+		{`{"project_name": "svc", "module_path": "Example.com/svc"}`, `module_path "Example.com/svc": host "Example.com" must be a lowercase domain`},
+		{`{"project_name": "svc", "module_path": "example.com/svc", "go_version": "2.0"}`, `go_version "2.0": expected a version like 1.23`},
+	}
+
+	for _, tt := range tests {
+		path := writeAnswers(t, tt.answers)
+		_, err := loadAnswers(path, &initOptions{GoVersion: "1.23"})
+		if err == nil {
+			t.Errorf("Expected error for %s", tt.answers)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.want) || !strings.Contains(err.Error(), path) {
+			t.Errorf("Expected error naming %s and %s, got: %v", path, tt.want, err)
+		}
+	}
+}
+
+func TestGenerateReadmeCustomTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "README.tmpl")
+	custom := "# {{.ProjectName}}\n\nOwned by {{.Author}}. Clone {{.CloneURL}}.\n{{if .EnableWorker}}Runs a worker.\n{{end}}"
+	if err := os.WriteFile(path, []byte(custom), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts, err := parseFlags([]string{"--readme-template", path})
+	if err != nil {
+		t.Fatalf("parseFlags() returned error: %v", err)
+	}
+
+	// The template is read up front, so it may be removed during init
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+
+	chdirTemp(t)
+	config := &ProjectConfig{ProjectName: "svc", ModulePath: "gitlab.com/acme/svc", Author: "Acme Team", EnableWorker: true}
+	if err := generateReadme(config, opts.readmeTemplate, newRollback()); err != nil {
+		t.Fatalf("generateReadme() returned error: %v", err)
+	}
+
+	content, err := os.ReadFile("README.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# svc\n\nOwned by Acme Team. Clone https://gitlab.com/acme/svc.git.\nRuns a worker.\n"
+	if string(content) != want {
+		t.Errorf("Expected README rendered from the custom template:\n%s\ngot:\n%s", want, content)
+	}
+}
+
+func TestParseFlagsInvalidReadmeTemplate(t *testing.T) {
+	dir := t.TempDir()
+	tests := map[string]string{
+		"syntax error":  "# {{.ProjectName}",
+		"unknown field": "# {{.ProjectTitle}}",
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, strings.ReplaceAll(name, " ", "-")+".tmpl")
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := parseFlags([]string{"--readme-template", path})
+			if err == nil || !strings.Contains(err.Error(), "invalid --readme-template "+path) {
+				t.Errorf("Expected an invalid --readme-template error, got %v", err)
+			}
+		})
+	}
+
+	if _, err := parseFlags([]string{"--readme-template", filepath.Join(dir, "missing.tmpl")}); err == nil {
+		t.Error("Expected error for a missing template file")
+	}
+}
+
+func TestGenerateDockerfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *ProjectConfig
+		want    []string
+		notWant []string
+	}{
+		{
+			name:   "server",
+			config: &ProjectConfig{GoVersion: "1.24", EnableCLI: true, EnableServer: true},
+			want: []string{
+				"FROM golang:1.24-alpine AS builder",
+				"-o /out/server ./cmd/server",
+				"EXPOSE 8080",
+				`CMD ["/usr/local/bin/cli", "healthcheck"`,
+				`ENTRYPOINT ["server"]`,
+				"FROM server AS default",
+			},
+			notWant: []string{"./cmd/worker", "AS worker"},
+		},
+		{
+			name:    "cli only",
+			config:  &ProjectConfig{GoVersion: "1.23", EnableCLI: true},
+			want:    []string{"-o /out/cli ./cmd/cli", `ENTRYPOINT ["cli"]`, "FROM cli AS default"},
+			notWant: []string{"EXPOSE", "HEALTHCHECK", "./cmd/server", "AS server"},
+		},
+		{
+			name:    "server without cli",
+			config:  &ProjectConfig{GoVersion: "1.23", EnableServer: true, EnableWorker: true},
+			want:    []string{"EXPOSE 8080", "AS worker", "FROM server AS default"},
+			notWant: []string{"/out/cli", "HEALTHCHECK --interval"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chdirTemp(t, "Dockerfile")
+
+			if err := generateDockerfile(tt.config, newRollback()); err != nil {
+				t.Fatalf("generateDockerfile() returned error: %v", err)
+			}
+
+			content, err := os.ReadFile("Dockerfile")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(content), want) {
+					t.Errorf("Expected Dockerfile to contain %q, got:\n%s", want, content)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(string(content), notWant) {
+					t.Errorf("Expected Dockerfile not to contain %q, got:\n%s", notWant, content)
+				}
+			}
+		})
+	}
+}
+
+func TestInitializeProjectOutputDirLeavesTemplateUntouched(t *testing.T) {
+	template := chdirTemp(t, "cmd/server/main.go", "scripts/init.go", "scripts/init_test.go")
+	files := map[string]string{
+		"go.mod":             "module " + templateModulePath + "\n",
+		"README.md":          templateReadmeTitle + "\n\nTemplate docs.\n",
+		"cmd/server/main.go": "package main\n\nimport _ \"" + templateModulePath + "/internal/app\"\n",
+		".git/HEAD":          "ref: refs/heads/main\n",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	snapshot := func() map[string]string {
+		t.Helper()
+		contents := make(map[string]string)
+		err := filepath.WalkDir(template, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			data, err := os.ReadFile(path)
+			contents[path] = string(data)
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return contents
+	}
+	before := snapshot()
+
+	var buf strings.Builder
+	origOut := out
+	out = &buf
+	defer func() { out = origOut }()
+
+	outputDir := filepath.Join(t.TempDir(), "svc")
+	config := &ProjectConfig{
+		ProjectName:  "svc",
+		ModulePath:   "github.com/example/svc",
+		GoVersion:    "1.23",
+		EnableCLI:    true,
+		EnableServer: true,
+	}
+	opts := &initOptions{GitTimeout: time.Second, SkipGit: true, OutputDir: outputDir}
+	if err := initializeProject(config, opts); err != nil {
+		t.Fatalf("initializeProject() returned error: %v\n%s", err, buf.String())
+	}
+
+	if after := snapshot(); !reflect.DeepEqual(before, after) {
+		t.Errorf("Expected the template to be untouched, before:\n%v\nafter:\n%v", before, after)
+	}
+
+	goMod, err := os.ReadFile(filepath.Join(outputDir, "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(goMod), "module github.com/example/svc") {
+		t.Errorf("Expected the copy's go.mod to use the new module path, got:\n%s", goMod)
+	}
+	serverMain, err := os.ReadFile(filepath.Join(outputDir, "cmd/server/main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(serverMain), "github.com/example/svc/internal/app") {
+		t.Errorf("Expected the copy's imports to be rewritten, got:\n%s", serverMain)
+	}
+	if exists(filepath.Join(outputDir, ".git")) {
+		t.Error("Expected the template's .git directory not to be copied")
+	}
+	if exists(filepath.Join(outputDir, "scripts/init.go")) {
+		t.Error("Expected the copy's init script to be removed")
+	}
+}
+
+func TestParseFlagsOutputDir(t *testing.T) {
+	dir := t.TempDir()
+
+	opts, err := parseFlags([]string{"--output-dir", filepath.Join(dir, "new")})
+	if err != nil {
+		t.Fatalf("parseFlags() returned error: %v", err)
+	}
+	if opts.OutputDir != filepath.Join(dir, "new") {
+		t.Errorf("Expected OutputDir %q, got %q", filepath.Join(dir, "new"), opts.OutputDir)
+	}
+
+	if _, err := parseFlags([]string{"--output-dir", dir}); err != nil {
+		t.Errorf("Expected an empty directory to be accepted, got %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "existing.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = parseFlags([]string{"--output-dir", dir})
+	if err == nil || !strings.Contains(err.Error(), "not empty") {
+		t.Errorf("Expected a non-empty directory to be rejected, got %v", err)
+	}
+}
+
+// initTemplateCopy initializes a copy of the repository's template with
+// config into a temporary directory and returns it. The checkout itself is
+// left untouched.
+func initTemplateCopy(t *testing.T, config *ProjectConfig) string {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("initializes and builds a full template copy")
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(".."); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(wd); err != nil {
+			t.Errorf("Failed to restore working directory: %v", err)
+		}
+	})
+
+	var buf strings.Builder
+	origOut := out
+	out = &buf
+	t.Cleanup(func() { out = origOut })
+
+	outputDir := filepath.Join(t.TempDir(), config.ProjectName)
+	opts := &initOptions{GitTimeout: time.Second, SkipGit: true, Force: true, OutputDir: outputDir}
+	if err := initializeProject(config, opts); err != nil {
+		t.Fatalf("initializeProject() returned error: %v\n%s", err, buf.String())
+	}
+	return outputDir
+}
+
+func TestInitializedWorkerOnlyProjectBuilds(t *testing.T) {
+	dir := initTemplateCopy(t, &ProjectConfig{
+		ProjectName:  "worker-svc",
+		ModulePath:   "github.com/example/worker-svc",
+		GoVersion:    "1.23",
+		EnableWorker: true,
+	})
+
+	cmd := exec.Command("go", "build", "./...")
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go build ./... failed in the generated project: %v\n%s", err, output)
+	}
+}
+
+func TestInitializedProjectE2EBuildsOnlyEnabledBinaries(t *testing.T) {
+	dir := initTemplateCopy(t, &ProjectConfig{
+		ProjectName:    "worker-svc",
+		ModulePath:     "github.com/example/worker-svc",
+		GoVersion:      "1.23",
+		EnableWorker:   true,
+		EnableE2ETests: true,
+	})
+
+	// Matching no tests still runs TestMain, which builds the binaries
+	cmd := exec.Command("go", "test", "-tags", "e2e", "-run", "^$", "./tests/e2e/")
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("e2e suite failed to start in the generated project: %v\n%s", err, output)
+	}
+}
@@ -4,7 +4,15 @@
 package e2e
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 )
@@ -15,8 +23,7 @@ func TestCLIApplicationLaunches(t *testing.T) {
 	t.Parallel()
 
 	// Arrange: Prepare CLI command
-	cmd := exec.Command("go", "run", "./cmd/cli")
-	cmd.Dir = getProjectRoot(t)
+	cmd := binaryCommand(context.Background(), "cli")
 
 	// Act: Run CLI with timeout
 	done := make(chan error, 1)
@@ -37,12 +44,12 @@ func TestCLIApplicationLaunches(t *testing.T) {
 			}
 		}
 		// Success: CLI ran and exited normally
-	case <-time.After(10 * time.Second):
-		// If CLI is still running after 10 seconds, kill it
+	case <-time.After(5 * time.Second):
+		// If CLI is still running after 5 seconds, kill it
 		if cmd.Process != nil {
 			cmd.Process.Kill()
 		}
-		t.Fatal("CLI did not exit within 10 seconds - may be hanging")
+		t.Fatal("CLI did not exit within 5 seconds - may be hanging")
 	}
 }
 
@@ -51,8 +58,7 @@ func TestCLIVersionFlag(t *testing.T) {
 	t.Parallel()
 
 	// Arrange: Prepare CLI command with --version
-	cmd := exec.Command("go", "run", "./cmd/cli", "--version")
-	cmd.Dir = getProjectRoot(t)
+	cmd := binaryCommand(context.Background(), "cli", "--version")
 
 	// Act: Execute command
 	output, err := cmd.CombinedOutput()
@@ -70,6 +76,70 @@ func TestCLIVersionFlag(t *testing.T) {
 	if !containsAppInfo(outputStr) {
 		t.Fatalf("CLI --version output doesn't contain expected app info: %s", outputStr)
 	}
+
+	// The binary is built for this machine, so it reports the same platform
+	if platform := runtime.GOOS + "/" + runtime.GOARCH; !strings.Contains(outputStr, platform) {
+		t.Errorf("CLI --version output doesn't name the platform %s: %s", platform, outputStr)
+	}
+}
+
+// TestCLIJSONOutput tests that --json prints machine-readable application info.
+func TestCLIJSONOutput(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: Prepare CLI command with --json
+	cmd := binaryCommand(context.Background(), "cli", "--json")
+
+	// Act: Execute command, keeping stdout separate from log output
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("CLI --json failed: %v", err)
+	}
+
+	// Assert: Output should be a JSON object with name and version
+	var info map[string]string
+	if err := json.Unmarshal(output, &info); err != nil {
+		t.Fatalf("CLI --json output is not a JSON object: %v\nOutput: %s", err, output)
+	}
+
+	for _, field := range []string{"name", "version"} {
+		if info[field] == "" {
+			t.Errorf("Expected field '%s' in CLI --json output: %s", field, output)
+		}
+	}
+}
+
+// TestCLIHealthcheck tests that the healthcheck subcommand exits 0 against a
+// running server and non-zero when nothing is listening.
+func TestCLIHealthcheck(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: Start a server to probe
+	_, baseURL, stop := startTestServer(t)
+	defer stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Act: Probe the running server
+	output, err := binaryCommand(ctx, "cli", "healthcheck", "--url", baseURL+"/health").CombinedOutput()
+
+	// Assert: Healthy server should exit 0 and print the status
+	if err != nil {
+		t.Fatalf("CLI healthcheck failed: %v\nOutput: %s", err, output)
+	}
+	if !contains(string(output), "200 OK") {
+		t.Errorf("Expected healthcheck output to include '200 OK', got: %s", output)
+	}
+
+	// Act: Probe a port with no server
+	unused := fmt.Sprintf("http://localhost:%d/health", getFreePort(t))
+	err = binaryCommand(ctx, "cli", "healthcheck", "--url", unused, "--timeout", "1s").Run()
+
+	// Assert: Unreachable server should exit non-zero
+	if _, ok := err.(*exec.ExitError); !ok {
+		t.Errorf("Expected non-zero exit for unreachable server, got %v", err)
+	}
 }
 
 // TestCLIHelp tests that the CLI provides help information.
@@ -82,8 +152,7 @@ func TestCLIHelp(t *testing.T) {
 	for _, flag := range testCases {
 		t.Run("flag_"+flag, func(t *testing.T) {
 			// Arrange: Prepare CLI command with help flag
-			cmd := exec.Command("go", "run", "./cmd/cli", flag)
-			cmd.Dir = getProjectRoot(t)
+			cmd := binaryCommand(context.Background(), "cli", flag)
 
 			// Act: Execute command
 			output, err := cmd.CombinedOutput()
@@ -110,8 +179,7 @@ func TestCLIInvalidFlag(t *testing.T) {
 	t.Parallel()
 
 	// Arrange: Prepare CLI command with invalid flag
-	cmd := exec.Command("go", "run", "./cmd/cli", "--invalid-flag-that-does-not-exist")
-	cmd.Dir = getProjectRoot(t)
+	cmd := binaryCommand(context.Background(), "cli", "--invalid-flag-that-does-not-exist")
 
 	// Act: Execute command
 	output, err := cmd.CombinedOutput()
@@ -132,6 +200,155 @@ func TestCLIInvalidFlag(t *testing.T) {
 	}
 }
 
+func TestCLIUnknownCommandExitCode(t *testing.T) {
+	t.Parallel()
+
+	cmd := binaryCommand(context.Background(), "cli", "no-such-command")
+	output, err := cmd.CombinedOutput()
+
+	var exitError *exec.ExitError
+	if !errors.As(err, &exitError) {
+		t.Fatalf("Expected CLI to exit with an error, got %v (output: %s)", err, output)
+	}
+	if code := exitError.ExitCode(); code != 2 {
+		t.Errorf("Expected usage exit code 2 for unknown command, got %d (output: %s)", code, output)
+	}
+}
+
+// TestCLIConfigPrintsEffectiveConfig tests that the config subcommand
+// reflects environment overrides in both formats while masking secrets.
+func TestCLIConfigPrintsEffectiveConfig(t *testing.T) {
+	t.Parallel()
+
+	env := append(os.Environ(),
+		"PORT=9123",
+		"LOG_LEVEL=debug",
+		"DATABASE_URL=postgres://app:hunter2@db:5432/app",
+	)
+
+	// Act: Print the config as JSON
+	cmd := binaryCommand(context.Background(), "cli", "config", "--format", "json")
+	cmd.Env = env
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("CLI config failed: %v\nOutput: %s", err, output)
+	}
+
+	// Assert: Overrides are visible and the database URL is redacted
+	var settings map[string]any
+	if err := json.Unmarshal(output, &settings); err != nil {
+		t.Fatalf("CLI config output is not a JSON object: %v\nOutput: %s", err, output)
+	}
+	if settings["port"] != float64(9123) {
+		t.Errorf("Expected port 9123, got %v", settings["port"])
+	}
+	if settings["log_level"] != "debug" {
+		t.Errorf("Expected log_level debug, got %v", settings["log_level"])
+	}
+	if settings["database_url"] != "postgres://***" {
+		t.Errorf("Expected database_url to be redacted, got %v", settings["database_url"])
+	}
+
+	// Act: Print the same config as YAML
+	cmd = binaryCommand(context.Background(), "cli", "config", "--format", "yaml")
+	cmd.Env = env
+	output, err = cmd.Output()
+	if err != nil {
+		t.Fatalf("CLI config --format yaml failed: %v\nOutput: %s", err, output)
+	}
+
+	yaml := string(output)
+	for _, line := range []string{"port: 9123\n", "log_level: \"debug\"\n", "database_url: \"postgres://***\"\n"} {
+		if !strings.Contains(yaml, line) {
+			t.Errorf("Expected YAML output to contain %q, got:\n%s", line, yaml)
+		}
+	}
+	if strings.Contains(yaml, "hunter2") {
+		t.Errorf("Expected database password to be redacted, got:\n%s", yaml)
+	}
+}
+
+// TestCLIConfigReadsConfigFile tests that config shows what a file-based
+// deployment sees, like the server does when CONFIG_FILE is set.
+func TestCLIConfigReadsConfigFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"port": 9345, "log_level": "warn"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Act: Print the config with CONFIG_FILE set
+	cmd := binaryCommand(context.Background(), "cli", "config", "--format", "json")
+	cmd.Env = append(os.Environ(), "CONFIG_FILE="+path, "PORT=9123")
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("CLI config failed: %v\nOutput: %s", err, output)
+	}
+
+	// Assert: The file wins over the environment
+	var settings map[string]any
+	if err := json.Unmarshal(output, &settings); err != nil {
+		t.Fatalf("CLI config output is not a JSON object: %v\nOutput: %s", err, output)
+	}
+	if settings["port"] != float64(9345) {
+		t.Errorf("Expected port 9345 from the config file, got %v", settings["port"])
+	}
+	if settings["log_level"] != "warn" {
+		t.Errorf("Expected log_level warn from the config file, got %v", settings["log_level"])
+	}
+}
+
+// TestCLIDoctor tests that doctor reports each check and exits non-zero only
+// when a critical check fails, using fake tools on a controlled PATH.
+func TestCLIDoctor(t *testing.T) {
+	t.Parallel()
+
+	// fakeTools returns a PATH directory holding the named shell scripts
+	fakeTools := func(scripts map[string]string) string {
+		dir := t.TempDir()
+		for name, body := range scripts {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+body+"\n"), 0o755); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return dir
+	}
+
+	t.Run("healthy", func(t *testing.T) {
+		path := fakeTools(map[string]string{"go": "echo go1.23.4", "git": "exit 0"})
+		cmd := binaryCommand(context.Background(), "cli", "doctor")
+		cmd.Env = []string{"PATH=" + path}
+
+		output, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("CLI doctor failed: %v\nOutput: %s", err, output)
+		}
+		for _, line := range []string{"PASS  go        go1.23.4", "PASS  git       " + filepath.Join(path, "git")} {
+			if !strings.Contains(string(output), line) {
+				t.Errorf("Expected doctor report to contain %q, got:\n%s", line, output)
+			}
+		}
+	})
+
+	t.Run("broken toolchain", func(t *testing.T) {
+		path := fakeTools(map[string]string{"go": "echo go1.20.1"})
+		cmd := binaryCommand(context.Background(), "cli", "doctor")
+		cmd.Env = []string{"PATH=" + path, "DATABASE_URL=postgres://app@127.0.0.1:1/app"}
+
+		output, err := cmd.Output()
+		var exitError *exec.ExitError
+		if !errors.As(err, &exitError) || exitError.ExitCode() != 1 {
+			t.Fatalf("Expected doctor to exit 1, got %v\nOutput: %s", err, output)
+		}
+		for _, prefix := range []string{"FAIL  go ", "FAIL  git ", "WARN  database "} {
+			if !strings.Contains(string(output), prefix) {
+				t.Errorf("Expected doctor report to contain %q, got:\n%s", prefix, output)
+			}
+		}
+	})
+}
+
 // Helper functions
 
 func containsAppInfo(output string) bool {
@@ -4,42 +4,150 @@
 package e2e
 
 import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
-// getProjectRoot finds the project root directory from any location within the project.
-// It works by looking for the go.mod file starting from the current directory and going up.
+var (
+	// projectRoot is the absolute path of the module root, resolved in TestMain.
+	projectRoot string
+
+	// binDir holds the binaries built once in TestMain.
+	binDir string
+)
+
+// binaries are the cmd/ programs built before the e2e tests run. Those
+// whose directory is missing, because init removed the component along
+// with its tests, are skipped.
+var binaries = []string{"cli", "server", "worker"}
+
+// getProjectRoot returns the project root directory resolved in TestMain.
 func getProjectRoot(t *testing.T) string {
-	wd, err := os.Getwd()
+	t.Helper()
+
+	if projectRoot == "" {
+		t.Fatal("Could not determine project root directory")
+	}
+	return projectRoot
+}
+
+// findProjectRoot walks up from the working directory until it finds go.mod.
+func findProjectRoot() (string, error) {
+	dir, err := os.Getwd()
 	if err != nil {
-		t.Fatalf("Failed to get working directory: %v", err)
+		return "", fmt.Errorf("failed to get working directory: %w", err)
 	}
 
-	// Try current directory first (CI might run from project root)
-	if _, err := os.Stat("go.mod"); err == nil {
-		return "."
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("could not find go.mod above working directory")
+		}
+		dir = parent
 	}
+}
+
+// buildBinaries compiles each of the binaries present under root into dir
+// with CGO disabled.
+func buildBinaries(root, dir string) error {
+	for _, name := range binaries {
+		if _, err := os.Stat(filepath.Join(root, "cmd", name)); os.IsNotExist(err) {
+			continue
+		}
+
+		cmd := exec.Command("go", "build", "-o", filepath.Join(dir, name), "./cmd/"+name)
+		cmd.Dir = root
+		cmd.Env = append(os.Environ(), "CGO_ENABLED=0")
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to build %s: %w\n%s", name, err, output)
+		}
+	}
+	return nil
+}
+
+// binaryCommand returns a command that runs the prebuilt binary with the given
+// name from the project root.
+func binaryCommand(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, filepath.Join(binDir, name), args...)
+	cmd.Dir = projectRoot
+	return cmd
+}
 
-	// If we're in tests/e2e, go up two levels
-	if dir := wd; len(dir) >= 8 && dir[len(dir)-8:] == "tests/e2e" {
-		return "../.."
+// startTestServer launches the prebuilt server on a free port with the extra
+// environment variables, waits until /health answers, and returns the running
+// command, its base URL, and a stop function that kills it.
+func startTestServer(t *testing.T, env ...string) (*exec.Cmd, string, func()) {
+	t.Helper()
+
+	port := getFreePort(t)
+	baseURL := fmt.Sprintf("http://localhost:%d", port)
+
+	cmd := binaryCommand(context.Background(), "server")
+	cmd.Env = append(os.Environ(), fmt.Sprintf("PORT=%d", port))
+	cmd.Env = append(cmd.Env, env...)
+
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Failed to start server: %v", err)
 	}
 
-	// Try going up one level (in case we're in tests/)
-	if _, err := os.Stat("../go.mod"); err == nil {
-		return ".."
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			cmd.Process.Kill()
+			cmd.Wait()
+		})
 	}
 
-	// Try going up two levels (in case we're in tests/e2e/)
-	if _, err := os.Stat("../../go.mod"); err == nil {
-		return "../.."
+	elapsed, ok := waitForServer(t, baseURL+"/health", 5*time.Second)
+	if !ok {
+		stop()
+		t.Fatal("Server did not start within timeout")
 	}
+	t.Logf("Server ready after %v", elapsed)
 
-	t.Logf("Working directory: %s", wd)
-	t.Fatal("Could not determine project root directory")
-	return ""
+	return cmd, baseURL, stop
+}
+
+// waitForServer polls url until it answers 200 OK, sleeping 20ms after the
+// first attempt and doubling the delay (up to a second) after each miss. It
+// returns how long the server took to become ready, so callers can assert on
+// slow starts, and false if timeout passes first.
+func waitForServer(t *testing.T, url string, timeout time.Duration) (time.Duration, bool) {
+	t.Helper()
+
+	client := &http.Client{Timeout: 1 * time.Second}
+	start := time.Now()
+	deadline := start.Add(timeout)
+	delay := 20 * time.Millisecond
+
+	for {
+		resp, err := client.Get(url)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return time.Since(start), true
+			}
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return time.Since(start), false
+		}
+		time.Sleep(min(delay, remaining))
+		delay = min(delay*2, time.Second)
+	}
 }
 
 // contains checks if a string contains a substring.
@@ -47,3 +155,17 @@ func getProjectRoot(t *testing.T) string {
 func contains(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
+
+// getFreePort asks the OS for an unused TCP port so tests that start servers
+// don't collide with each other or with whatever else owns a fixed port.
+func getFreePort(t *testing.T) int {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatalf("Failed to find a free port: %v", err)
+	}
+	defer listener.Close()
+
+	return listener.Addr().(*net.TCPAddr).Port
+}
@@ -0,0 +1,48 @@
+//go:build e2e
+// +build e2e
+
+package e2e
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestDatabaseMigrationsArePaired tests that every up migration has a matching
+// down migration so deployments can be rolled back.
+func TestDatabaseMigrationsArePaired(t *testing.T) {
+	migrationsDir := filepath.Join(getProjectRoot(t), "migrations")
+
+	entries, err := os.ReadDir(migrationsDir)
+	if err != nil {
+		t.Fatalf("Failed to read migrations directory: %v", err)
+	}
+
+	ups := 0
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasSuffix(name, ".up.sql") {
+			continue
+		}
+		ups++
+
+		down := strings.TrimSuffix(name, ".up.sql") + ".down.sql"
+		if _, err := os.Stat(filepath.Join(migrationsDir, down)); err != nil {
+			t.Errorf("Migration %s has no matching %s", name, down)
+		}
+
+		content, err := os.ReadFile(filepath.Join(migrationsDir, name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		if len(strings.TrimSpace(string(content))) == 0 {
+			t.Errorf("Migration %s is empty", name)
+		}
+	}
+
+	if ups == 0 {
+		t.Fatal("No up migrations found")
+	}
+}
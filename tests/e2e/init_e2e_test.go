@@ -10,6 +10,7 @@ import (
 	"strings"
 	"testing"
 	"time"
+	"unicode"
 )
 
 // TestInitScriptBasicFunctionality tests that the init script can run without errors.
@@ -35,17 +36,23 @@ func TestInitScriptBasicFunctionality(t *testing.T) {
 	// This simulates user input for project configuration
 	input := strings.Join([]string{
 		"test-project",                      // Project name
+		"",                                  // Git remote (empty)
 		"github.com/test-org/test-project",  // Module path
 		"A test project for E2E validation", // Description
 		"Test User",                         // Author name
 		"test@example.com",                  // Author email
 		"MIT",                               // License
+		"",                                  // Go version (detected default)
 		"y",                                 // Include CLI
 		"y",                                 // Include server
 		"n",                                 // Include worker
+		"n",                                 // Include scheduler
+		"n",                                 // Include gRPC service
+		"n",                                 // Include database layer
 		"y",                                 // Include docs
+		"y",                                 // Include integration tests
+		"y",                                 // Include smoke tests
 		"n",                                 // Include E2E tests
-		"",                                  // Git remote (empty)
 		"y",                                 // Confirm initialization
 	}, "\n") + "\n"
 
@@ -90,14 +97,19 @@ func TestInitScriptValidation(t *testing.T) {
 		cmd.Dir = tmpDir
 		cmd.Env = append(os.Environ(), "CGO_ENABLED=0")
 
-		// Provide invalid project name (starts with number)
-		input := "123-invalid-name\n"
+		// Provide invalid project names until the attempts run out
+		input := "invalid_name\n-invalid\ninvalid name\n"
 		cmd.Stdin = strings.NewReader(input)
 
-		// Should fail or ask for valid input
-		err := cmd.Run()
+		output, err := cmd.CombinedOutput()
 		if err == nil {
-			t.Log("Init script may have asked for valid input (expected behavior)")
+			t.Fatalf("Expected init to fail after repeated invalid names, output:\n%s", output)
+		}
+		if !strings.Contains(string(output), `"invalid_name" must contain only letters, numbers, and hyphens`) {
+			t.Errorf("Expected the rejection reason before re-prompting, output:\n%s", output)
+		}
+		if !strings.Contains(string(output), `invalid project name "invalid name"`) {
+			t.Errorf("Expected the final error to name the rejected value, output:\n%s", output)
 		}
 	})
 
@@ -111,18 +123,27 @@ func TestInitScriptValidation(t *testing.T) {
 		cmd.Dir = tmpDir
 		cmd.Env = append(os.Environ(), "CGO_ENABLED=0")
 
-		// Provide project name then invalid module path
+		// Provide project name, no remote, then invalid module paths until
+		// the attempts run out
 		input := strings.Join([]string{
 			"valid-project",
+			"",
+			"invalid-module-path-no-slash",
+			"Example.com/valid-project",
 			"invalid-module-path-no-slash",
 		}, "\n") + "\n"
 
 		cmd.Stdin = strings.NewReader(input)
 
-		// Should fail or ask for valid input
-		err := cmd.Run()
+		output, err := cmd.CombinedOutput()
 		if err == nil {
-			t.Log("Init script may have asked for valid input (expected behavior)")
+			t.Fatalf("Expected init to fail after repeated invalid module paths, output:\n%s", output)
+		}
+		if !strings.Contains(string(output), `host "Example.com" must be a lowercase domain name`) {
+			t.Errorf("Expected the rejection reason before re-prompting, output:\n%s", output)
+		}
+		if !strings.Contains(string(output), `invalid module path "invalid-module-path-no-slash": must be a host followed by a path`) {
+			t.Errorf("Expected a precise module path error, output:\n%s", output)
 		}
 	})
 }
@@ -145,17 +166,23 @@ func TestInitScriptFileGeneration(t *testing.T) {
 
 	input := strings.Join([]string{
 		"example-project",
+		"", // No git remote
 		"github.com/example/example-project",
 		"An example project",
 		"Example User",
 		"user@example.com",
 		"MIT",
+		"",  // Go version (detected default)
 		"y", // CLI
 		"n", // Server (disabled to test removal)
 		"n", // Worker (disabled to test removal)
+		"n", // Scheduler (disabled to test removal)
+		"n", // gRPC (disabled to test removal)
+		"n", // Database (disabled to test removal)
 		"y", // Docs
+		"y", // Integration tests
+		"y", // Smoke tests
 		"n", // E2E tests (disabled to test removal)
-		"",  // No git remote
 		"y", // Confirm
 	}, "\n") + "\n"
 
@@ -186,6 +213,12 @@ func TestInitScriptFileGeneration(t *testing.T) {
 	unwantedFiles := []string{
 		"cmd/server",
 		"internal/handlers",
+		"cmd/scheduler",
+		"internal/cron",
+		"cmd/grpc",
+		"proto",
+		"internal/store",
+		"migrations",
 	}
 
 	for _, file := range unwantedFiles {
@@ -199,6 +232,343 @@ func TestInitScriptFileGeneration(t *testing.T) {
 	verifyGoModUpdated(t, tmpDir, "github.com/example/example-project")
 }
 
+// TestInitScriptRerunIsSafe tests that running init on an already-initialized
+// project refuses instead of rewriting the customized files.
+func TestInitScriptRerunIsSafe(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E init rerun test in short mode")
+	}
+
+	// Arrange: Initialize a project once
+	tmpDir := createTempProjectDir(t)
+	defer cleanupTempDir(t, tmpDir)
+	projectRoot := getProjectRoot(t)
+	copyTemplateFiles(t, projectRoot, tmpDir)
+
+	input := strings.Join([]string{
+		"rerun-project",
+		"", // No git remote
+		"github.com/example/rerun-project",
+		"A project initialized twice",
+		"Example User",
+		"user@example.com",
+		"MIT",
+		"",  // Go version (detected default)
+		"y", // CLI
+		"y", // Server
+		"n", // Worker
+		"n", // Scheduler
+		"n", // gRPC
+		"n", // Database
+		"n", // Docs
+		"y", // Integration tests
+		"y", // Smoke tests
+		"n", // E2E tests
+		"y", // Confirm
+	}, "\n") + "\n"
+
+	first := exec.Command("go", "run", "scripts/init.go")
+	first.Dir = tmpDir
+	first.Env = append(os.Environ(), "CGO_ENABLED=0", "SKIP_GIT_INIT=1")
+	first.Stdin = strings.NewReader(input)
+	if output, err := first.CombinedOutput(); err != nil {
+		t.Fatalf("First init run failed: %v\n%s", err, output)
+	}
+
+	goModBefore, err := os.ReadFile(filepath.Join(tmpDir, "go.mod"))
+	if err != nil {
+		t.Fatalf("Failed to read go.mod: %v", err)
+	}
+	readmeBefore, err := os.ReadFile(filepath.Join(tmpDir, "README.md"))
+	if err != nil {
+		t.Fatalf("Failed to read README.md: %v", err)
+	}
+
+	// Act: Restore the init script (it removes itself) and run it again
+	if err := copyFile(filepath.Join(projectRoot, "scripts/init.go"), filepath.Join(tmpDir, "scripts/init.go")); err != nil {
+		t.Fatalf("Failed to restore init script: %v", err)
+	}
+
+	second := exec.Command("go", "run", "scripts/init.go")
+	second.Dir = tmpDir
+	second.Env = append(os.Environ(), "CGO_ENABLED=0", "SKIP_GIT_INIT=1")
+	second.Stdin = strings.NewReader(input)
+	output, err := second.CombinedOutput()
+
+	// Assert: Second run refuses and leaves the project untouched
+	if err == nil {
+		t.Fatalf("Expected second init run to fail, output:\n%s", output)
+	}
+	if !strings.Contains(string(output), "already initialized") {
+		t.Errorf("Expected 'already initialized' in output, got:\n%s", output)
+	}
+
+	goModAfter, err := os.ReadFile(filepath.Join(tmpDir, "go.mod"))
+	if err != nil {
+		t.Fatalf("Failed to read go.mod: %v", err)
+	}
+	if string(goModAfter) != string(goModBefore) {
+		t.Errorf("go.mod changed on second run:\nbefore: %s\nafter: %s", goModBefore, goModAfter)
+	}
+
+	readmeAfter, err := os.ReadFile(filepath.Join(tmpDir, "README.md"))
+	if err != nil {
+		t.Fatalf("Failed to read README.md: %v", err)
+	}
+	if string(readmeAfter) != string(readmeBefore) {
+		t.Error("README.md changed on second run")
+	}
+
+	// The project should still build after the refused re-run
+	build := exec.Command("go", "build", "./...")
+	build.Dir = tmpDir
+	build.Env = append(os.Environ(), "CGO_ENABLED=0")
+	if output, err := build.CombinedOutput(); err != nil {
+		t.Errorf("Project no longer builds after second run: %v\n%s", err, output)
+	}
+}
+
+// TestInitScriptRollbackOnFailure tests that a failure partway through init
+// restores rewritten files and leaves components in place.
+func TestInitScriptRollbackOnFailure(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E init rollback test in short mode")
+	}
+
+	// Arrange: Copy the template and block README generation with a directory,
+	// which makes init fail after go.mod and import paths have been rewritten
+	tmpDir := createTempProjectDir(t)
+	defer cleanupTempDir(t, tmpDir)
+	projectRoot := getProjectRoot(t)
+	copyTemplateFiles(t, projectRoot, tmpDir)
+
+	if err := os.MkdirAll(filepath.Join(tmpDir, "README.md"), 0o755); err != nil {
+		t.Fatalf("Failed to create blocking README.md directory: %v", err)
+	}
+
+	goModBefore, err := os.ReadFile(filepath.Join(tmpDir, "go.mod"))
+	if err != nil {
+		t.Fatalf("Failed to read go.mod: %v", err)
+	}
+	serverMainBefore, err := os.ReadFile(filepath.Join(tmpDir, "cmd/server/main.go"))
+	if err != nil {
+		t.Fatalf("Failed to read cmd/server/main.go: %v", err)
+	}
+
+	// Act: Run init selecting a configuration that would remove components
+	cmd := exec.Command("go", "run", "scripts/init.go")
+	cmd.Dir = tmpDir
+	cmd.Env = append(os.Environ(), "CGO_ENABLED=0", "SKIP_GIT_INIT=1")
+	cmd.Stdin = strings.NewReader(strings.Join([]string{
+		"rollback-project",
+		"", // No git remote
+		"github.com/example/rollback-project",
+		"A project whose init fails",
+		"Example User",
+		"user@example.com",
+		"MIT",
+		"",  // Go version (detected default)
+		"y", // CLI
+		"y", // Server
+		"n", // Worker (would be removed)
+		"n", // Scheduler
+		"n", // gRPC (would be removed)
+		"n", // Database (would be removed)
+		"y", // Docs
+		"y", // Integration tests
+		"y", // Smoke tests
+		"n", // E2E tests
+		"y", // Confirm
+	}, "\n") + "\n")
+
+	output, err := cmd.CombinedOutput()
+
+	// Assert: Init fails and the directory is back in its original state
+	if err == nil {
+		t.Fatalf("Expected init to fail, output:\n%s", output)
+	}
+	if !strings.Contains(string(output), "restoring original files") {
+		t.Errorf("Expected restore message in output, got:\n%s", output)
+	}
+
+	goModAfter, err := os.ReadFile(filepath.Join(tmpDir, "go.mod"))
+	if err != nil {
+		t.Fatalf("Failed to read go.mod: %v", err)
+	}
+	if string(goModAfter) != string(goModBefore) {
+		t.Errorf("go.mod was not restored:\n%s", goModAfter)
+	}
+
+	serverMainAfter, err := os.ReadFile(filepath.Join(tmpDir, "cmd/server/main.go"))
+	if err != nil {
+		t.Fatalf("Failed to read cmd/server/main.go: %v", err)
+	}
+	if string(serverMainAfter) != string(serverMainBefore) {
+		t.Error("cmd/server/main.go import paths were not restored")
+	}
+
+	for _, path := range []string{"cmd/worker", "cmd/grpc", "proto", "internal/store", "scripts/init.go"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, path)); err != nil {
+			t.Errorf("Expected %s to be kept after failed init: %v", path, err)
+		}
+	}
+}
+
+// TestInitScriptPlainOutput tests that --no-emoji produces ASCII-only output
+// across prompts, initialization steps and cleanup.
+func TestInitScriptPlainOutput(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E init plain output test in short mode")
+	}
+
+	tmpDir := createTempProjectDir(t)
+	defer cleanupTempDir(t, tmpDir)
+	copyTemplateFiles(t, getProjectRoot(t), tmpDir)
+
+	cmd := exec.Command("go", "run", "scripts/init.go", "--no-emoji")
+	cmd.Dir = tmpDir
+	cmd.Env = append(os.Environ(), "CGO_ENABLED=0", "SKIP_GIT_INIT=1")
+	cmd.Stdin = strings.NewReader(strings.Join([]string{
+		"plain-project",
+		"", // No git remote
+		"github.com/example/plain-project",
+		"A project initialized without emoji",
+		"Example User",
+		"user@example.com",
+		"MIT",
+		"",  // Go version (detected default)
+		"y", // CLI
+		"y", // Server
+		"n", // Worker
+		"n", // Scheduler
+		"n", // gRPC
+		"n", // Database
+		"y", // Docs
+		"y", // Integration tests
+		"y", // Smoke tests
+		"n", // E2E tests
+		"y", // Confirm
+	}, "\n") + "\n")
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Init script failed: %v\n%s", err, output)
+	}
+
+	for _, r := range string(output) {
+		if r > unicode.MaxASCII {
+			t.Fatalf("Expected ASCII-only output, found %q in:\n%s", r, output)
+		}
+	}
+
+	if !strings.Contains(string(output), "[ok] Project initialized successfully!") {
+		t.Errorf("Expected plain success message, got:\n%s", output)
+	}
+}
+
+// TestInitScriptSkipGit tests that --skip-git leaves the project without a
+// git repository.
+func TestInitScriptSkipGit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E init skip-git test in short mode")
+	}
+
+	tmpDir := createTempProjectDir(t)
+	defer cleanupTempDir(t, tmpDir)
+	copyTemplateFiles(t, getProjectRoot(t), tmpDir)
+
+	// Drop SKIP_GIT_INIT so only the flag can suppress git
+	var env []string
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, "SKIP_GIT_INIT=") {
+			env = append(env, kv)
+		}
+	}
+
+	cmd := exec.Command("go", "run", "scripts/init.go", "--skip-git")
+	cmd.Dir = tmpDir
+	cmd.Env = append(env, "CGO_ENABLED=0")
+	cmd.Stdin = strings.NewReader(strings.Join([]string{
+		"nogit-project",
+		"", // No git remote
+		"github.com/example/nogit-project",
+		"A project initialized without git",
+		"Example User",
+		"user@example.com",
+		"MIT",
+		"",  // Go version (detected default)
+		"y", // CLI
+		"n", // Server
+		"n", // Worker
+		"n", // Scheduler
+		"n", // gRPC
+		"n", // Database
+		"n", // Docs
+		"y", // Integration tests
+		"y", // Smoke tests
+		"n", // E2E tests
+		"y", // Confirm
+	}, "\n") + "\n")
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Init script failed: %v\n%s", err, output)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, ".git")); !os.IsNotExist(err) {
+		t.Errorf("Expected no .git directory with --skip-git, stat error: %v", err)
+	}
+
+	if !strings.Contains(string(output), "Skipping git init") {
+		t.Errorf("Expected output to state git was skipped, got:\n%s", output)
+	}
+}
+
+// TestInitScriptKeepInit tests that --keep-init leaves the init script in place.
+func TestInitScriptKeepInit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping E2E init keep-init test in short mode")
+	}
+
+	tmpDir := createTempProjectDir(t)
+	defer cleanupTempDir(t, tmpDir)
+	copyTemplateFiles(t, getProjectRoot(t), tmpDir)
+
+	cmd := exec.Command("go", "run", "scripts/init.go", "--keep-init")
+	cmd.Dir = tmpDir
+	cmd.Env = append(os.Environ(), "CGO_ENABLED=0", "SKIP_GIT_INIT=1")
+	cmd.Stdin = strings.NewReader(strings.Join([]string{
+		"kept-project",
+		"", // No git remote
+		"github.com/example/kept-project",
+		"A project that keeps its init script",
+		"Example User",
+		"user@example.com",
+		"MIT",
+		"",  // Go version (detected default)
+		"y", // CLI
+		"n", // Server
+		"n", // Worker
+		"n", // Scheduler
+		"n", // gRPC
+		"n", // Database
+		"n", // Docs
+		"y", // Integration tests
+		"y", // Smoke tests
+		"n", // E2E tests
+		"y", // Confirm
+	}, "\n") + "\n")
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Init script failed: %v\n%s", err, output)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "scripts", "init.go")); err != nil {
+		t.Errorf("Expected scripts/init.go to be kept with --keep-init: %v", err)
+	}
+}
+
 // Helper functions for init script tests
 
 func createTempProjectDir(t *testing.T) string {
@@ -241,6 +611,8 @@ func copyTemplateFiles(t *testing.T, srcDir, dstDir string) {
 	dirs := []string{
 		"cmd",
 		"internal",
+		"migrations",
+		"proto",
 		"scripts",
 		"docs",
 		".github",
@@ -4,15 +4,15 @@
 package e2e
 
 import (
-	"context"
 	"io"
 	"net/http"
-	"os"
 	"os/exec"
 	"strings"
 	"syscall"
 	"testing"
 	"time"
+
+	"github.com/your-org/go-template-project/internal/testutil"
 )
 
 // TestServerApplicationLaunches tests that the HTTP server can start and respond to requests.
@@ -22,40 +22,9 @@ func TestServerApplicationLaunches(t *testing.T) {
 		t.Skip("Skipping E2E server test in short mode")
 	}
 
-	// Arrange: Start server in background
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	cmd := exec.CommandContext(ctx, "go", "run", "./cmd/server")
-	cmd.Dir = getProjectRoot(t)
-
-	// Set test environment
-	cmd.Env = append(os.Environ(),
-		"CGO_ENABLED=0", // Disable CGO for CI compatibility
-		"PORT=8081",     // Use different port to avoid conflicts
-		"DEBUG=true",
-	)
-
-	// Start server
-	if err := cmd.Start(); err != nil {
-		t.Fatalf("Failed to start server: %v", err)
-	}
-
-	// Ensure server is killed at end of test
-	defer func() {
-		if cmd.Process != nil {
-			cmd.Process.Kill()
-			cmd.Wait()
-		}
-	}()
-
-	// Act: Wait for server to start and test endpoints
-	serverURL := "http://localhost:8081"
-
-	// Wait for server to be ready
-	if !waitForServer(t, serverURL+"/health", 10*time.Second) {
-		t.Fatal("Server did not start within timeout")
-	}
+	// Arrange: Start server in background and wait for it to be ready
+	_, serverURL, stop := startTestServer(t, "DEBUG=true")
+	defer stop()
 
 	// Assert: Test that server responds correctly
 	testServerEndpoints(t, serverURL)
@@ -67,35 +36,8 @@ func TestServerHealthEndpoint(t *testing.T) {
 		t.Skip("Skipping E2E server test in short mode")
 	}
 
-	// This test assumes server is running (could be started by docker-compose or manually)
-	// For CI, we'll use a different port to avoid conflicts
-	serverURL := "http://localhost:8082"
-
-	// Try to start a server instance for this test
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
-	defer cancel()
-
-	cmd := exec.CommandContext(ctx, "go", "run", "./cmd/server")
-	cmd.Dir = getProjectRoot(t)
-	cmd.Env = append(os.Environ(), "CGO_ENABLED=0", "PORT=8082", "DEBUG=false")
-
-	if err := cmd.Start(); err != nil {
-		t.Skipf("Could not start server for health test: %v", err)
-		return
-	}
-
-	defer func() {
-		if cmd.Process != nil {
-			cmd.Process.Kill()
-			cmd.Wait()
-		}
-	}()
-
-	// Wait for server to start
-	if !waitForServer(t, serverURL+"/health", 8*time.Second) {
-		t.Skip("Server did not start in time for health test")
-		return
-	}
+	_, serverURL, stop := startTestServer(t, "DEBUG=false")
+	defer stop()
 
 	// Test health endpoint
 	resp, err := http.Get(serverURL + "/health")
@@ -120,15 +62,10 @@ func TestServerHealthEndpoint(t *testing.T) {
 		t.Fatalf("Failed to read health check response: %v", err)
 	}
 
-	bodyStr := string(body)
-	if len(bodyStr) == 0 {
-		t.Fatal("Health check response body is empty")
-	}
-
-	// Should contain health status (flexible to avoid coupling to exact JSON structure)
-	if !containsHealthInfo(bodyStr) {
-		t.Fatalf("Health check response doesn't contain expected health info: %s", bodyStr)
-	}
+	testutil.AssertJSON(t, body, map[string]any{
+		"status":    "healthy",
+		"timestamp": testutil.Present,
+	})
 }
 
 // TestServerGracefulShutdown tests that the server shuts down gracefully.
@@ -138,23 +75,7 @@ func TestServerGracefulShutdown(t *testing.T) {
 	}
 
 	// Arrange: Start server
-	cmd := exec.Command("go", "run", "./cmd/server")
-	cmd.Dir = getProjectRoot(t)
-	cmd.Env = append(os.Environ(), "CGO_ENABLED=0", "PORT=8083", "DEBUG=true")
-
-	if err := cmd.Start(); err != nil {
-		t.Fatalf("Failed to start server for shutdown test: %v", err)
-	}
-
-	serverURL := "http://localhost:8083"
-
-	// Wait for server to start
-	if !waitForServer(t, serverURL+"/health", 8*time.Second) {
-		if cmd.Process != nil {
-			cmd.Process.Kill()
-		}
-		t.Fatal("Server did not start for shutdown test")
-	}
+	cmd, _, _ := startTestServer(t, "DEBUG=true")
 
 	// Act: Send SIGTERM signal (graceful shutdown)
 	if err := cmd.Process.Signal(syscall.SIGTERM); err != nil {
@@ -193,23 +114,6 @@ func TestServerGracefulShutdown(t *testing.T) {
 
 // Helper functions for server tests
 
-func waitForServer(t *testing.T, url string, timeout time.Duration) bool {
-	client := &http.Client{Timeout: 1 * time.Second}
-	deadline := time.Now().Add(timeout)
-
-	for time.Now().Before(deadline) {
-		resp, err := client.Get(url)
-		if err == nil {
-			resp.Body.Close()
-			if resp.StatusCode == http.StatusOK {
-				return true
-			}
-		}
-		time.Sleep(200 * time.Millisecond)
-	}
-	return false
-}
-
 func testServerEndpoints(t *testing.T, baseURL string) {
 	client := &http.Client{Timeout: 5 * time.Second}
 
@@ -256,9 +160,10 @@ func testServerEndpoints(t *testing.T, baseURL string) {
 			t.Fatalf("Failed to read API info response: %v", err)
 		}
 
-		if !containsAPIInfo(string(body)) {
-			t.Fatalf("API info response doesn't contain expected info: %s", body)
-		}
+		testutil.AssertJSON(t, body, map[string]any{
+			"name":    testutil.Present,
+			"version": testutil.Present,
+		})
 	})
 
 	// Test invalid endpoint (should return 404)
@@ -274,18 +179,3 @@ func testServerEndpoints(t *testing.T, baseURL string) {
 		}
 	})
 }
-
-func containsHealthInfo(body string) bool {
-	// Look for health-related information without coupling to exact JSON structure
-	return len(body) > 5 && (contains(body, "healthy") ||
-		contains(body, "status") ||
-		contains(body, "timestamp") ||
-		contains(body, "version"))
-}
-
-func containsAPIInfo(body string) bool {
-	// Look for API information patterns
-	return len(body) > 5 && (contains(body, "name") ||
-		contains(body, "version") ||
-		contains(body, "go-template"))
-}
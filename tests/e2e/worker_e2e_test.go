@@ -25,11 +25,10 @@ func TestWorkerApplicationLaunches(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "go", "run", "./cmd/worker")
-	cmd.Dir = getProjectRoot(t)
+	cmd := binaryCommand(ctx, "worker")
 
 	// Set test environment with debug enabled to get more output
-	cmd.Env = append(os.Environ(), "CGO_ENABLED=0", "DEBUG=true")
+	cmd.Env = append(os.Environ(), "DEBUG=true")
 
 	// Start worker
 	if err := cmd.Start(); err != nil {
@@ -97,9 +96,8 @@ func TestWorkerTaskProcessing(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "go", "run", "./cmd/worker")
-	cmd.Dir = getProjectRoot(t)
-	cmd.Env = append(os.Environ(), "CGO_ENABLED=0", "DEBUG=true")
+	cmd := binaryCommand(ctx, "worker")
+	cmd.Env = append(os.Environ(), "DEBUG=true")
 
 	// Capture output to verify worker is processing tasks
 	stdout, err := cmd.StdoutPipe()
@@ -185,10 +183,8 @@ func TestWorkerConfiguration(t *testing.T) {
 			ctx, cancel := context.WithTimeout(context.Background(), 8*time.Second)
 			defer cancel()
 
-			cmd := exec.CommandContext(ctx, "go", "run", "./cmd/worker")
-			cmd.Dir = getProjectRoot(t)
-			cmd.Env = append(os.Environ(), "CGO_ENABLED=0")
-			cmd.Env = append(cmd.Env, "WORKER_TASK_INTERVAL=2s") // Faster for testing
+			cmd := binaryCommand(ctx, "worker")
+			cmd.Env = append(os.Environ(), "WORKER_TASK_INTERVAL=2s") // Faster for testing
 			cmd.Env = append(cmd.Env, tc.env...)
 
 			// Capture output
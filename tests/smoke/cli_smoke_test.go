@@ -0,0 +1,19 @@
+//go:build smoke
+// +build smoke
+
+package smoke
+
+import (
+	"testing"
+
+	"github.com/your-org/go-template-project/internal/app"
+)
+
+// TestAppGreetingRuns checks the CLI application's default command.
+func TestAppGreetingRuns(t *testing.T) {
+	application := app.New("smoke-app", "1.0.0")
+
+	if err := application.Run(); err != nil {
+		t.Fatalf("Run() returned error: %v", err)
+	}
+}
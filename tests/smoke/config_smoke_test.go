@@ -0,0 +1,25 @@
+//go:build smoke
+// +build smoke
+
+package smoke
+
+import (
+	"testing"
+
+	"github.com/your-org/go-template-project/internal/config"
+)
+
+// TestConfigLoads checks that the default configuration loads and validates.
+func TestConfigLoads(t *testing.T) {
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load() returned error: %v", err)
+	}
+
+	if cfg.Port == 0 {
+		t.Error("Expected a default port")
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected loaded config to be valid, got %v", err)
+	}
+}
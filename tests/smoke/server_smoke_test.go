@@ -0,0 +1,37 @@
+//go:build smoke
+// +build smoke
+
+package smoke
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/your-org/go-template-project/internal/handlers"
+	"github.com/your-org/go-template-project/internal/metrics"
+)
+
+// TestRouterServesHealth builds the full router in-process and checks the
+// operational routes answer.
+func TestRouterServesHealth(t *testing.T) {
+	router := handlers.NewRouter(handlers.RouterOptions{
+		Name:      "smoke-app",
+		Version:   "1.0.0",
+		Readiness: handlers.NewReadinessRegistry(1),
+		Metrics:   metrics.New(),
+	})
+
+	if err := router.SelfCheck(); err != nil {
+		t.Fatalf("Router self-check failed: %v", err)
+	}
+
+	for _, path := range []string{"/health", "/ready", "/api/info"} {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("Expected %s to return %d, got %d", path, http.StatusOK, rr.Code)
+		}
+	}
+}